@@ -31,15 +31,25 @@ func main() {
 		}
 	}
 
-	reqFile, err := os.Open(requestPath)
+	reqBytes, err := os.ReadFile(requestPath)
 	if err != nil {
-		log.Fatalf("failed to open code generator request file: %s", err.Error())
+		log.Fatalf("failed to read code generator request file: %s", err.Error())
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(reqBytes, req); err != nil {
+		log.Fatalf("failed to unmarshal code generator request: %s", err.Error())
+	}
+
+	module.PrepareRequest(req)
+	if reqBytes, err = proto.Marshal(req); err != nil {
+		log.Fatalf("failed to marshal code generator request: %s", err.Error())
 	}
 
 	resBytes := &bytes.Buffer{}
 	pgs.Init(
 		pgs.DebugEnv(common.DebugEnv),
-		pgs.ProtocInput(reqFile),
+		pgs.ProtocInput(bytes.NewReader(reqBytes)),
 		pgs.ProtocOutput(resBytes),
 	).RegisterModule(module.New()).Render()
 
@@ -0,0 +1,155 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/cerbos/protoc-gen-jsonschema/internal/module"
+)
+
+const (
+	generateCommand = "generate"
+
+	// Field numbers of buf.alpha.image.v1.ImageFileExtension, which buf attaches to the files of an image.
+	imageFileExtensionField = 8042
+	imageFileIsImportField  = 1
+)
+
+var errNoDescriptorSet = errors.New("--descriptor-set is required")
+
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func generate(args []string) error {
+	flags := flag.NewFlagSet(generateCommand, flag.ContinueOnError)
+	descriptorSet := flags.String("descriptor-set", "", "Path to a FileDescriptorSet or buf image, as produced by `buf build -o`")
+	out := flags.String("out", ".", "Directory to write the schemas to")
+	var files, params stringsFlag
+	flags.Var(&files, "file", "Proto file to generate schemas for (repeatable). Defaults to every file that is not an import")
+	flags.Var(&params, "param", "Plugin parameter as key=value (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *descriptorSet == "" {
+		return errNoDescriptorSet
+	}
+
+	req, err := buildRequest(*descriptorSet, files, params)
+	if err != nil {
+		return err
+	}
+
+	generated, err := module.Generate(req, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range generated {
+		path := filepath.Join(*out, filepath.FromSlash(file.GetName()))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+
+		if err := os.WriteFile(path, []byte(file.GetContent()), 0o644); err != nil { //nolint:gosec
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func buildRequest(descriptorSetPath string, files, params []string) (*pluginpb.CodeGeneratorRequest, error) {
+	data, err := os.ReadFile(descriptorSetPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+
+	descriptorSet := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, descriptorSet); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal descriptor set: %w", err)
+	}
+
+	if len(files) == 0 {
+		for _, file := range descriptorSet.GetFile() {
+			if !isImport(file) {
+				files = append(files, file.GetName())
+			}
+		}
+	}
+
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: files,
+		Parameter:      proto.String(strings.Join(params, ",")),
+		ProtoFile:      descriptorSet.GetFile(),
+	}, nil
+}
+
+// isImport reports whether buf marked the file as an import when it built the image.
+// Plain descriptor sets carry no such marker, so all of their files are generated.
+func isImport(file *descriptorpb.FileDescriptorProto) bool {
+	unknown := file.ProtoReflect().GetUnknown()
+	for len(unknown) > 0 {
+		number, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return false
+		}
+		unknown = unknown[n:]
+
+		if number != imageFileExtensionField || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(number, typ, unknown)
+			if n < 0 {
+				return false
+			}
+			unknown = unknown[n:]
+			continue
+		}
+
+		extension, n := protowire.ConsumeBytes(unknown)
+		if n < 0 {
+			return false
+		}
+		unknown = unknown[n:]
+
+		for len(extension) > 0 {
+			number, typ, n := protowire.ConsumeTag(extension)
+			if n < 0 {
+				return false
+			}
+			extension = extension[n:]
+
+			if number == imageFileIsImportField && typ == protowire.VarintType {
+				value, n := protowire.ConsumeVarint(extension)
+				return n >= 0 && value != 0
+			}
+
+			n = protowire.ConsumeFieldValue(number, typ, extension)
+			if n < 0 {
+				return false
+			}
+			extension = extension[n:]
+		}
+	}
+
+	return false
+}
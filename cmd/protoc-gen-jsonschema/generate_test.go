@@ -0,0 +1,232 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		image  bool
+		args   []string
+		want   []string
+		absent []string
+	}{
+		{
+			name:   "buf_image",
+			image:  true,
+			want:   []string{"app/Main.schema.json"},
+			absent: []string{"dep/Dep.schema.json"},
+		},
+		{
+			name: "descriptor_set",
+			want: []string{"app/Main.schema.json", "dep/Dep.schema.json"},
+		},
+		{
+			name:   "file",
+			args:   []string{"--file", "app/main.proto"},
+			want:   []string{"app/Main.schema.json"},
+			absent: []string{"dep/Dep.schema.json"},
+		},
+		{
+			name:   "buf_image_file",
+			image:  true,
+			args:   []string{"--file", "dep/dep.proto"},
+			want:   []string{"dep/Dep.schema.json"},
+			absent: []string{"app/Main.schema.json"},
+		},
+		{
+			name:   "params",
+			image:  true,
+			args:   []string{"--param", "layout=file", "--param", "field_names=proto"},
+			want:   []string{"app/main.schema.json"},
+			absent: []string{"app/Main.schema.json"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			descriptorSet := filepath.Join(dir, "image.binpb")
+			writeDescriptorSet(t, descriptorSet, tc.image)
+
+			out := filepath.Join(dir, "out")
+			require.NoError(t, generate(append([]string{"--descriptor-set", descriptorSet, "--out", out}, tc.args...)))
+
+			for _, name := range tc.want {
+				require.FileExists(t, filepath.Join(out, name))
+			}
+
+			for _, name := range tc.absent {
+				require.NoFileExists(t, filepath.Join(out, name))
+			}
+		})
+	}
+
+	t.Run("param_values", func(t *testing.T) {
+		dir := t.TempDir()
+		descriptorSet := filepath.Join(dir, "image.binpb")
+		writeDescriptorSet(t, descriptorSet, true)
+
+		out := filepath.Join(dir, "out")
+		require.NoError(t, generate([]string{"--descriptor-set", descriptorSet, "--out", out, "--param", "field_names=proto"}))
+
+		data, err := os.ReadFile(filepath.Join(out, "app", "Main.schema.json"))
+		require.NoError(t, err)
+
+		var schema map[string]any
+		require.NoError(t, json.Unmarshal(data, &schema))
+		require.Contains(t, schema["properties"], "dep_value")
+		require.NotContains(t, schema["properties"], "depValue")
+	})
+
+	t.Run("unknown_flag", func(t *testing.T) {
+		require.ErrorContains(t, generate([]string{"--unknown"}), "flag provided but not defined: -unknown")
+	})
+
+	t.Run("help", func(t *testing.T) {
+		require.ErrorIs(t, generate([]string{"-h"}), flag.ErrHelp)
+	})
+
+	t.Run("no_descriptor_set", func(t *testing.T) {
+		require.ErrorIs(t, generate(nil), errNoDescriptorSet)
+	})
+
+	t.Run("missing_descriptor_set", func(t *testing.T) {
+		err := generate([]string{"--descriptor-set", filepath.Join(t.TempDir(), "missing.binpb")})
+		require.ErrorContains(t, err, "failed to read descriptor set")
+	})
+
+	t.Run("invalid_param", func(t *testing.T) {
+		dir := t.TempDir()
+		descriptorSet := filepath.Join(dir, "image.binpb")
+		writeDescriptorSet(t, descriptorSet, true)
+
+		err := generate([]string{"--descriptor-set", descriptorSet, "--out", dir, "--param", "layout=none"})
+		require.ErrorContains(t, err, `invalid layout parameter "none"`)
+	})
+}
+
+func TestIsImport(t *testing.T) {
+	testCases := []struct {
+		name    string
+		unknown []byte
+		want    bool
+	}{
+		{
+			name: "no_extension",
+		},
+		{
+			name:    "import",
+			unknown: imageFileExtension(true),
+			want:    true,
+		},
+		{
+			name:    "not_import",
+			unknown: imageFileExtension(false),
+		},
+		{
+			name:    "after_other_fields",
+			unknown: append(protowire.AppendVarint(protowire.AppendTag(nil, 9000, protowire.VarintType), 1), imageFileExtension(true)...),
+			want:    true,
+		},
+		{
+			name:    "truncated",
+			unknown: imageFileExtension(true)[:4],
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			file := &descriptorpb.FileDescriptorProto{Name: proto.String("test.proto")}
+			file.ProtoReflect().SetUnknown(tc.unknown)
+			require.Equal(t, tc.want, isImport(file))
+		})
+	}
+}
+
+// writeDescriptorSet writes app/main.proto and the dep/dep.proto file that it imports.
+// A buf image marks dep/dep.proto as an import, whereas a descriptor set built by protoc does not.
+func writeDescriptorSet(t *testing.T, path string, image bool) {
+	t.Helper()
+
+	dep := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("dep/dep.proto"),
+		Package: proto.String("dep"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Dep"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("name"),
+				JsonName: proto.String("name"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			}},
+		}},
+	}
+
+	app := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("app/main.proto"),
+		Package:    proto.String("app"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"dep/dep.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Main"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("dep_value"),
+				JsonName: proto.String("depValue"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".dep.Dep"),
+			}},
+		}},
+	}
+
+	if image {
+		dep.ProtoReflect().SetUnknown(imageFileExtension(true))
+		app.ProtoReflect().SetUnknown(imageFileExtension(false))
+	}
+
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{dep, app}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// imageFileExtension encodes the buf.alpha.image.v1.ImageFileExtension that buf attaches to files. Its module_info
+// field comes before is_import, so that isImport has to skip it.
+func imageFileExtension(isImport bool) []byte {
+	var name []byte
+	name = protowire.AppendTag(name, 1, protowire.BytesType)
+	name = protowire.AppendString(name, "buf.build")
+	name = protowire.AppendTag(name, 2, protowire.BytesType)
+	name = protowire.AppendString(name, "acme")
+	name = protowire.AppendTag(name, 3, protowire.BytesType)
+	name = protowire.AppendString(name, "dep")
+
+	var moduleInfo []byte
+	moduleInfo = protowire.AppendTag(moduleInfo, 1, protowire.BytesType)
+	moduleInfo = protowire.AppendBytes(moduleInfo, name)
+
+	var extension []byte
+	extension = protowire.AppendTag(extension, 2, protowire.BytesType)
+	extension = protowire.AppendBytes(extension, moduleInfo)
+	extension = protowire.AppendTag(extension, imageFileIsImportField, protowire.VarintType)
+	extension = protowire.AppendVarint(extension, protowire.EncodeBool(isImport))
+
+	unknown := protowire.AppendTag(nil, imageFileExtensionField, protowire.BytesType)
+	return protowire.AppendBytes(unknown, extension)
+}
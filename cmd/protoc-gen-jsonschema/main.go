@@ -4,14 +4,86 @@
 package main
 
 import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
 	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/pluginpb"
 
 	"github.com/cerbos/protoc-gen-jsonschema/internal/common"
 	"github.com/cerbos/protoc-gen-jsonschema/internal/module"
 )
 
+var supportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL | pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+
 func main() {
-	supportedFeatures := uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-	pgs.Init(pgs.SupportedFeatures(&supportedFeatures), pgs.DebugEnv(common.DebugEnv)).RegisterModule(module.New()).Render()
+	if len(os.Args) > 1 && os.Args[1] == generateCommand {
+		if err := generate(os.Args[2:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return
+			}
+
+			fmt.Fprintf(os.Stderr, "failed to generate schemas: %s\n", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	input, err := readRequest()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read request: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	// pgs does not declare the editions that the plugin supports, so the response is amended before protoc sees it.
+	output := &bytes.Buffer{}
+	pgs.Init(
+		pgs.SupportedFeatures(&supportedFeatures),
+		pgs.DebugEnv(common.DebugEnv),
+		pgs.ProtocInput(bytes.NewReader(input)),
+		pgs.ProtocOutput(output),
+	).RegisterModule(module.New()).Render()
+
+	if err := writeResponse(output.Bytes()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write response: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func readRequest() ([]byte, error) {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		return nil, err
+	}
+
+	module.PrepareRequest(req)
+	return proto.Marshal(req)
+}
+
+func writeResponse(data []byte) error {
+	res := &pluginpb.CodeGeneratorResponse{}
+	if err := proto.Unmarshal(data, res); err != nil {
+		return err
+	}
+
+	res.MinimumEdition = proto.Int32(int32(module.MinimumEdition))
+	res.MaximumEdition = proto.Int32(int32(module.MaximumEdition))
+
+	data, err := proto.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(data)
+	return err
 }
@@ -0,0 +1,615 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: jsonschema/options.proto
+
+package jsonschemapb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Options that apply to a file. The schemas of its messages are customised with message and field options instead, so
+// that each schema is described where its message is declared.
+type FileOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Do not generate schema documents for the messages in this file.
+	// The messages are still defined wherever other schemas refer to them.
+	Skip bool `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
+	// Title of the document generated for the file with layout=file, instead of the file path.
+	Title *string `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// Description of the document generated for the file with layout=file.
+	Description   *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileOptions) Reset() {
+	*x = FileOptions{}
+	mi := &file_jsonschema_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileOptions) ProtoMessage() {}
+
+func (x *FileOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonschema_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileOptions.ProtoReflect.Descriptor instead.
+func (*FileOptions) Descriptor() ([]byte, []int) {
+	return file_jsonschema_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FileOptions) GetSkip() bool {
+	if x != nil {
+		return x.Skip
+	}
+	return false
+}
+
+func (x *FileOptions) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *FileOptions) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+// Options that customise the schema generated for a message.
+type MessageOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Title of the schema, instead of the message name.
+	Title *string `protobuf:"bytes,1,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// Description of the schema, instead of the message comments.
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// JSON-encoded example values.
+	Examples []string `protobuf:"bytes,3,rep,name=examples,proto3" json:"examples,omitempty"`
+	// JSON-encoded default value.
+	Default *string `protobuf:"bytes,4,opt,name=default,proto3,oneof" json:"default,omitempty"`
+	// Mark the message as deprecated.
+	Deprecated bool `protobuf:"varint,5,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// JSON object whose keywords are merged into the generated schema, replacing any generated keywords with the same name.
+	Extra *string `protobuf:"bytes,6,opt,name=extra,proto3,oneof" json:"extra,omitempty"`
+	// JSON schema to use instead of the generated one.
+	Override      *string `protobuf:"bytes,7,opt,name=override,proto3,oneof" json:"override,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageOptions) Reset() {
+	*x = MessageOptions{}
+	mi := &file_jsonschema_options_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageOptions) ProtoMessage() {}
+
+func (x *MessageOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonschema_options_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageOptions.ProtoReflect.Descriptor instead.
+func (*MessageOptions) Descriptor() ([]byte, []int) {
+	return file_jsonschema_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MessageOptions) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *MessageOptions) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *MessageOptions) GetExamples() []string {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *MessageOptions) GetDefault() string {
+	if x != nil && x.Default != nil {
+		return *x.Default
+	}
+	return ""
+}
+
+func (x *MessageOptions) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *MessageOptions) GetExtra() string {
+	if x != nil && x.Extra != nil {
+		return *x.Extra
+	}
+	return ""
+}
+
+func (x *MessageOptions) GetOverride() string {
+	if x != nil && x.Override != nil {
+		return *x.Override
+	}
+	return ""
+}
+
+// Options that customise the schema generated for a field.
+type FieldOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Title of the schema.
+	Title *string `protobuf:"bytes,1,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// Description of the schema, instead of the field comments.
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// JSON-encoded example values.
+	Examples []string `protobuf:"bytes,3,rep,name=examples,proto3" json:"examples,omitempty"`
+	// JSON-encoded default value.
+	Default *string `protobuf:"bytes,4,opt,name=default,proto3,oneof" json:"default,omitempty"`
+	// Value of the format keyword.
+	Format *string `protobuf:"bytes,5,opt,name=format,proto3,oneof" json:"format,omitempty"`
+	// Mark the field as deprecated.
+	Deprecated bool `protobuf:"varint,6,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// Mark the field as read-only.
+	ReadOnly bool `protobuf:"varint,7,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// Mark the field as write-only.
+	WriteOnly bool `protobuf:"varint,8,opt,name=write_only,json=writeOnly,proto3" json:"write_only,omitempty"`
+	// Leave the field out of the schema, so that documents which set it are rejected.
+	Hidden bool `protobuf:"varint,9,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	// JSON object whose keywords are merged into the generated schema, replacing any generated keywords with the same name.
+	Extra *string `protobuf:"bytes,10,opt,name=extra,proto3,oneof" json:"extra,omitempty"`
+	// JSON schema to use instead of the generated one.
+	Override      *string `protobuf:"bytes,11,opt,name=override,proto3,oneof" json:"override,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldOptions) Reset() {
+	*x = FieldOptions{}
+	mi := &file_jsonschema_options_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldOptions) ProtoMessage() {}
+
+func (x *FieldOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonschema_options_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldOptions.ProtoReflect.Descriptor instead.
+func (*FieldOptions) Descriptor() ([]byte, []int) {
+	return file_jsonschema_options_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FieldOptions) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetExamples() []string {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+func (x *FieldOptions) GetDefault() string {
+	if x != nil && x.Default != nil {
+		return *x.Default
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *FieldOptions) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *FieldOptions) GetWriteOnly() bool {
+	if x != nil {
+		return x.WriteOnly
+	}
+	return false
+}
+
+func (x *FieldOptions) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+func (x *FieldOptions) GetExtra() string {
+	if x != nil && x.Extra != nil {
+		return *x.Extra
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetOverride() string {
+	if x != nil && x.Override != nil {
+		return *x.Override
+	}
+	return ""
+}
+
+// Options that customise the schema generated for an enum.
+type EnumOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Title of the schema, instead of the enum name.
+	Title *string `protobuf:"bytes,1,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// Description of the schema, instead of the enum comments.
+	Description *string `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Mark the enum as deprecated.
+	Deprecated bool `protobuf:"varint,3,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// JSON object whose keywords are merged into the generated schema, replacing any generated keywords with the same name.
+	Extra *string `protobuf:"bytes,4,opt,name=extra,proto3,oneof" json:"extra,omitempty"`
+	// JSON schema to use instead of the generated one.
+	Override *string `protobuf:"bytes,5,opt,name=override,proto3,oneof" json:"override,omitempty"`
+	// Leave deprecated values out of the schema, unless they are required by validation rules.
+	HideDeprecatedValues bool `protobuf:"varint,6,opt,name=hide_deprecated_values,json=hideDeprecatedValues,proto3" json:"hide_deprecated_values,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *EnumOptions) Reset() {
+	*x = EnumOptions{}
+	mi := &file_jsonschema_options_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnumOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumOptions) ProtoMessage() {}
+
+func (x *EnumOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_jsonschema_options_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnumOptions.ProtoReflect.Descriptor instead.
+func (*EnumOptions) Descriptor() ([]byte, []int) {
+	return file_jsonschema_options_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EnumOptions) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *EnumOptions) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *EnumOptions) GetDeprecated() bool {
+	if x != nil {
+		return x.Deprecated
+	}
+	return false
+}
+
+func (x *EnumOptions) GetExtra() string {
+	if x != nil && x.Extra != nil {
+		return *x.Extra
+	}
+	return ""
+}
+
+func (x *EnumOptions) GetOverride() string {
+	if x != nil && x.Override != nil {
+		return *x.Override
+	}
+	return ""
+}
+
+func (x *EnumOptions) GetHideDeprecatedValues() bool {
+	if x != nil {
+		return x.HideDeprecatedValues
+	}
+	return false
+}
+
+var file_jsonschema_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: (*FileOptions)(nil),
+		Field:         51171,
+		Name:          "jsonschema.file",
+		Tag:           "bytes,51171,opt,name=file",
+		Filename:      "jsonschema/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*MessageOptions)(nil),
+		Field:         51171,
+		Name:          "jsonschema.message",
+		Tag:           "bytes,51171,opt,name=message",
+		Filename:      "jsonschema/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldOptions)(nil),
+		Field:         51171,
+		Name:          "jsonschema.field",
+		Tag:           "bytes,51171,opt,name=field",
+		Filename:      "jsonschema/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumOptions)(nil),
+		ExtensionType: (*EnumOptions)(nil),
+		Field:         51171,
+		Name:          "jsonschema.enum",
+		Tag:           "bytes,51171,opt,name=enum",
+		Filename:      "jsonschema/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FileOptions.
+var (
+	// optional jsonschema.FileOptions file = 51171;
+	E_File = &file_jsonschema_options_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional jsonschema.MessageOptions message = 51171;
+	E_Message = &file_jsonschema_options_proto_extTypes[1]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional jsonschema.FieldOptions field = 51171;
+	E_Field = &file_jsonschema_options_proto_extTypes[2]
+)
+
+// Extension fields to descriptorpb.EnumOptions.
+var (
+	// optional jsonschema.EnumOptions enum = 51171;
+	E_Enum = &file_jsonschema_options_proto_extTypes[3]
+)
+
+var File_jsonschema_options_proto protoreflect.FileDescriptor
+
+const file_jsonschema_options_proto_rawDesc = "" +
+	"\n" +
+	"\x18jsonschema/options.proto\x12\n" +
+	"jsonschema\x1a google/protobuf/descriptor.proto\"}\n" +
+	"\vFileOptions\x12\x12\n" +
+	"\x04skip\x18\x01 \x01(\bR\x04skip\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_description\"\xa6\x02\n" +
+	"\x0eMessageOptions\x12\x19\n" +
+	"\x05title\x18\x01 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1a\n" +
+	"\bexamples\x18\x03 \x03(\tR\bexamples\x12\x1d\n" +
+	"\adefault\x18\x04 \x01(\tH\x02R\adefault\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\x05 \x01(\bR\n" +
+	"deprecated\x12\x19\n" +
+	"\x05extra\x18\x06 \x01(\tH\x03R\x05extra\x88\x01\x01\x12\x1f\n" +
+	"\boverride\x18\a \x01(\tH\x04R\boverride\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\n" +
+	"\n" +
+	"\b_defaultB\b\n" +
+	"\x06_extraB\v\n" +
+	"\t_override\"\xa0\x03\n" +
+	"\fFieldOptions\x12\x19\n" +
+	"\x05title\x18\x01 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1a\n" +
+	"\bexamples\x18\x03 \x03(\tR\bexamples\x12\x1d\n" +
+	"\adefault\x18\x04 \x01(\tH\x02R\adefault\x88\x01\x01\x12\x1b\n" +
+	"\x06format\x18\x05 \x01(\tH\x03R\x06format\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\x06 \x01(\bR\n" +
+	"deprecated\x12\x1b\n" +
+	"\tread_only\x18\a \x01(\bR\breadOnly\x12\x1d\n" +
+	"\n" +
+	"write_only\x18\b \x01(\bR\twriteOnly\x12\x16\n" +
+	"\x06hidden\x18\t \x01(\bR\x06hidden\x12\x19\n" +
+	"\x05extra\x18\n" +
+	" \x01(\tH\x04R\x05extra\x88\x01\x01\x12\x1f\n" +
+	"\boverride\x18\v \x01(\tH\x05R\boverride\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\n" +
+	"\n" +
+	"\b_defaultB\t\n" +
+	"\a_formatB\b\n" +
+	"\x06_extraB\v\n" +
+	"\t_override\"\x92\x02\n" +
+	"\vEnumOptions\x12\x19\n" +
+	"\x05title\x18\x01 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\x03 \x01(\bR\n" +
+	"deprecated\x12\x19\n" +
+	"\x05extra\x18\x04 \x01(\tH\x02R\x05extra\x88\x01\x01\x12\x1f\n" +
+	"\boverride\x18\x05 \x01(\tH\x03R\boverride\x88\x01\x01\x124\n" +
+	"\x16hide_deprecated_values\x18\x06 \x01(\bR\x14hideDeprecatedValuesB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_extraB\v\n" +
+	"\t_override:N\n" +
+	"\x04file\x12\x1c.google.protobuf.FileOptions\x18\xe3\x8f\x03 \x01(\v2\x17.jsonschema.FileOptionsR\x04file\x88\x01\x01:Z\n" +
+	"\amessage\x12\x1f.google.protobuf.MessageOptions\x18\xe3\x8f\x03 \x01(\v2\x1a.jsonschema.MessageOptionsR\amessage\x88\x01\x01:R\n" +
+	"\x05field\x12\x1d.google.protobuf.FieldOptions\x18\xe3\x8f\x03 \x01(\v2\x18.jsonschema.FieldOptionsR\x05field\x88\x01\x01:N\n" +
+	"\x04enum\x12\x1c.google.protobuf.EnumOptions\x18\xe3\x8f\x03 \x01(\v2\x17.jsonschema.EnumOptionsR\x04enum\x88\x01\x01BHZFgithub.com/cerbos/protoc-gen-jsonschema/gen/pb/jsonschema;jsonschemapbb\x06proto3"
+
+var (
+	file_jsonschema_options_proto_rawDescOnce sync.Once
+	file_jsonschema_options_proto_rawDescData []byte
+)
+
+func file_jsonschema_options_proto_rawDescGZIP() []byte {
+	file_jsonschema_options_proto_rawDescOnce.Do(func() {
+		file_jsonschema_options_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_jsonschema_options_proto_rawDesc), len(file_jsonschema_options_proto_rawDesc)))
+	})
+	return file_jsonschema_options_proto_rawDescData
+}
+
+var file_jsonschema_options_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_jsonschema_options_proto_goTypes = []any{
+	(*FileOptions)(nil),                 // 0: jsonschema.FileOptions
+	(*MessageOptions)(nil),              // 1: jsonschema.MessageOptions
+	(*FieldOptions)(nil),                // 2: jsonschema.FieldOptions
+	(*EnumOptions)(nil),                 // 3: jsonschema.EnumOptions
+	(*descriptorpb.FileOptions)(nil),    // 4: google.protobuf.FileOptions
+	(*descriptorpb.MessageOptions)(nil), // 5: google.protobuf.MessageOptions
+	(*descriptorpb.FieldOptions)(nil),   // 6: google.protobuf.FieldOptions
+	(*descriptorpb.EnumOptions)(nil),    // 7: google.protobuf.EnumOptions
+}
+var file_jsonschema_options_proto_depIdxs = []int32{
+	4, // 0: jsonschema.file:extendee -> google.protobuf.FileOptions
+	5, // 1: jsonschema.message:extendee -> google.protobuf.MessageOptions
+	6, // 2: jsonschema.field:extendee -> google.protobuf.FieldOptions
+	7, // 3: jsonschema.enum:extendee -> google.protobuf.EnumOptions
+	0, // 4: jsonschema.file:type_name -> jsonschema.FileOptions
+	1, // 5: jsonschema.message:type_name -> jsonschema.MessageOptions
+	2, // 6: jsonschema.field:type_name -> jsonschema.FieldOptions
+	3, // 7: jsonschema.enum:type_name -> jsonschema.EnumOptions
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	4, // [4:8] is the sub-list for extension type_name
+	0, // [0:4] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_jsonschema_options_proto_init() }
+func file_jsonschema_options_proto_init() {
+	if File_jsonschema_options_proto != nil {
+		return
+	}
+	file_jsonschema_options_proto_msgTypes[0].OneofWrappers = []any{}
+	file_jsonschema_options_proto_msgTypes[1].OneofWrappers = []any{}
+	file_jsonschema_options_proto_msgTypes[2].OneofWrappers = []any{}
+	file_jsonschema_options_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jsonschema_options_proto_rawDesc), len(file_jsonschema_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 4,
+			NumServices:   0,
+		},
+		GoTypes:           file_jsonschema_options_proto_goTypes,
+		DependencyIndexes: file_jsonschema_options_proto_depIdxs,
+		MessageInfos:      file_jsonschema_options_proto_msgTypes,
+		ExtensionInfos:    file_jsonschema_options_proto_extTypes,
+	}.Build()
+	File_jsonschema_options_proto = out.File
+	file_jsonschema_options_proto_goTypes = nil
+	file_jsonschema_options_proto_depIdxs = nil
+}
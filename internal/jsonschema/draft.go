@@ -0,0 +1,46 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import "fmt"
+
+type Draft string
+
+const (
+	Draft07     Draft = "07"
+	Draft201909 Draft = "2019-09"
+	Draft202012 Draft = "2020-12"
+)
+
+func ParseDraft(value string) (Draft, error) {
+	switch draft := Draft(value); draft {
+	case Draft07, Draft201909, Draft202012:
+		return draft, nil
+	default:
+		return "", fmt.Errorf("unsupported JSON schema draft %q (expected one of %q, %q or %q)", value, Draft07, Draft201909, Draft202012)
+	}
+}
+
+func (d Draft) MetaSchema() string {
+	switch d {
+	case Draft201909:
+		return "https://json-schema.org/draft/2019-09/schema"
+	case Draft202012:
+		return "https://json-schema.org/draft/2020-12/schema"
+	default:
+		return "http://json-schema.org/draft-07/schema#"
+	}
+}
+
+func (d Draft) DefinitionsKeyword() string {
+	if d == Draft07 {
+		return "definitions"
+	}
+
+	return "$defs"
+}
+
+func (d Draft) DefinitionRef(key string) string {
+	return "#/" + d.DefinitionsKeyword() + "/" + key
+}
@@ -3,19 +3,48 @@
 
 package jsonschema
 
-//nolint:govet
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// CELRule is a protovalidate CEL rule, which validators cannot evaluate but other tools can.
+type CELRule struct {
+	ID         string `json:"id,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Expression string `json:"expression"`
+}
+
+type Annotations struct {
+	Default    json.RawMessage
+	Examples   []json.RawMessage
+	CEL        []CELRule
+	Deprecated bool
+	ReadOnly   bool
+	WriteOnly  bool
+}
+
+//nolint:govet,tagliatelle
 type GenericSchema struct {
-	ID          string             `json:"$id,omitempty"`
-	Version     string             `json:"$schema,omitempty"`
-	Ref         string             `json:"$ref,omitempty"`
-	Definitions map[string]Schema  `json:"definitions,omitempty"`
-	Title       string             `json:"title,omitempty"`
-	Description string             `json:"description,omitempty"`
-	Type        string             `json:"type,omitempty"`
-	AllOf       []NonTrivialSchema `json:"allOf,omitempty"`
-	AnyOf       []NonTrivialSchema `json:"anyOf,omitempty"`
-	OneOf       []NonTrivialSchema `json:"oneOf,omitempty"`
-	Not         Schema             `json:"not,omitempty"`
+	ID                  string             `json:"$id,omitempty"`
+	Version             string             `json:"$schema,omitempty"`
+	Ref                 string             `json:"$ref,omitempty"`
+	Defs                map[string]Schema  `json:"$defs,omitempty"`
+	Definitions         map[string]Schema  `json:"definitions,omitempty"`
+	Title               string             `json:"title,omitempty"`
+	Description         string             `json:"description,omitempty"`
+	MarkdownDescription string             `json:"markdownDescription,omitempty"`
+	Default             json.RawMessage    `json:"default,omitempty"`
+	Examples            []json.RawMessage  `json:"examples,omitempty"`
+	Deprecated          bool               `json:"deprecated,omitempty"`
+	ReadOnly            bool               `json:"readOnly,omitempty"`
+	WriteOnly           bool               `json:"writeOnly,omitempty"`
+	CEL                 []CELRule          `json:"x-cel,omitempty"`
+	Type                string             `json:"type,omitempty"`
+	AllOf               []NonTrivialSchema `json:"allOf,omitempty"`
+	AnyOf               []NonTrivialSchema `json:"anyOf,omitempty"`
+	OneOf               []NonTrivialSchema `json:"oneOf,omitempty"`
+	Not                 Schema             `json:"not,omitempty"`
 }
 
 func Ref(ref string) *GenericSchema {
@@ -50,13 +79,56 @@ func Not(schema Schema) *GenericSchema {
 	return &GenericSchema{Not: schema}
 }
 
-func (s *GenericSchema) Define(definitions map[string]Schema) {
-	s.Definitions = definitions
+func (s *GenericSchema) Annotate(annotations Annotations) {
+	if annotations.Default != nil {
+		s.Default = annotations.Default
+	}
+
+	if len(annotations.Examples) > 0 {
+		s.Examples = annotations.Examples
+	}
+
+	if len(annotations.CEL) > 0 {
+		s.CEL = append(s.CEL, annotations.CEL...)
+	}
+
+	s.Deprecated = s.Deprecated || annotations.Deprecated
+	s.ReadOnly = s.ReadOnly || annotations.ReadOnly
+	s.WriteOnly = s.WriteOnly || annotations.WriteOnly
+}
+
+func (s *GenericSchema) Define(draft Draft, definitions map[string]Schema) {
+	if draft == Draft07 {
+		s.Definitions = definitions
+	} else {
+		s.Defs = definitions
+	}
+}
+
+func (s *GenericSchema) Document(title, description string) {
+	if title != "" {
+		s.Title = title
+	}
+
+	if description != "" {
+		s.Description = description
+		s.MarkdownDescription = description
+	}
+}
+
+// IsolateRef moves $ref into allOf if other keywords sit beside it, because draft 07 ignores them.
+func (s *GenericSchema) IsolateRef() {
+	if s.Ref == "" || reflect.DeepEqual(*s, GenericSchema{Ref: s.Ref}) {
+		return
+	}
+
+	s.AllOf = append([]NonTrivialSchema{Ref(s.Ref)}, s.AllOf...)
+	s.Ref = ""
 }
 
-func (s *GenericSchema) TopLevel(id string) {
+func (s *GenericSchema) TopLevel(draft Draft, id string) {
 	s.ID = id
-	s.Version = "http://json-schema.org/draft-07/schema#"
+	s.Version = draft.MetaSchema()
 }
 
 func (*GenericSchema) implementsSchema() {}
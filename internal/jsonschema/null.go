@@ -0,0 +1,8 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+func NewNullSchema() *GenericSchema {
+	return &GenericSchema{Type: "null"}
+}
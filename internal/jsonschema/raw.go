@@ -0,0 +1,123 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+)
+
+var errNotAnObject = errors.New("schema must be a JSON object")
+
+type RawSchema struct {
+	keywords map[string]any
+}
+
+func NewRawSchema(data []byte) (*RawSchema, error) {
+	keywords, err := unmarshalObject(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RawSchema{keywords: keywords}, nil
+}
+
+// Merge combines the keywords of a schema with the keywords of a JSON object, which take precedence.
+func Merge(schema Schema, data []byte) (*RawSchema, error) {
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	keywords, err := unmarshalObject(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := unmarshalObject(data)
+	if err != nil {
+		return nil, err
+	}
+
+	maps.Copy(keywords, overrides)
+	return &RawSchema{keywords: keywords}, nil
+}
+
+func unmarshalObject(data []byte) (map[string]any, error) {
+	var keywords map[string]any
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schema: %w", err)
+	}
+
+	if keywords == nil {
+		return nil, errNotAnObject
+	}
+
+	return keywords, nil
+}
+
+func (s *RawSchema) Annotate(annotations Annotations) {
+	if annotations.Default != nil {
+		s.keywords["default"] = annotations.Default
+	}
+
+	if len(annotations.Examples) > 0 {
+		s.keywords["examples"] = annotations.Examples
+	}
+
+	if len(annotations.CEL) > 0 {
+		s.keywords["x-cel"] = annotations.CEL
+	}
+
+	if annotations.Deprecated {
+		s.keywords["deprecated"] = true
+	}
+
+	if annotations.ReadOnly {
+		s.keywords["readOnly"] = true
+	}
+
+	if annotations.WriteOnly {
+		s.keywords["writeOnly"] = true
+	}
+}
+
+func (s *RawSchema) Define(draft Draft, definitions map[string]Schema) {
+	s.keywords[draft.DefinitionsKeyword()] = definitions
+}
+
+func (s *RawSchema) Document(title, description string) {
+	if title != "" {
+		s.keywords["title"] = title
+	}
+
+	if description != "" {
+		s.keywords["description"] = description
+		s.keywords["markdownDescription"] = description
+	}
+}
+
+func (s *RawSchema) IsolateRef() {
+	ref, ok := s.keywords["$ref"]
+	if !ok || len(s.keywords) == 1 {
+		return
+	}
+
+	allOf, _ := s.keywords["allOf"].([]any)
+	s.keywords["allOf"] = append([]any{map[string]any{"$ref": ref}}, allOf...)
+	delete(s.keywords, "$ref")
+}
+
+func (s *RawSchema) TopLevel(draft Draft, id string) {
+	s.keywords["$id"] = id
+	s.keywords["$schema"] = draft.MetaSchema()
+}
+
+func (s *RawSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.keywords)
+}
+
+func (*RawSchema) implementsSchema() {}
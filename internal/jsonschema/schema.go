@@ -14,8 +14,11 @@ type Schema interface {
 
 type NonTrivialSchema interface {
 	Schema
-	Define(definitions map[string]Schema)
-	TopLevel(id string)
+	Annotate(annotations Annotations)
+	Define(draft Draft, definitions map[string]Schema)
+	Document(title, description string)
+	IsolateRef()
+	TopLevel(draft Draft, id string)
 }
 
 type TrivialSchema bool
@@ -13,17 +13,26 @@ const (
 	StringFormatIPv6         StringFormat = "ipv6"
 	StringFormatURI          StringFormat = "uri"
 	StringFormatURIReference StringFormat = "uri-reference"
+	StringFormatUUID         StringFormat = "uuid"
 )
 
-//nolint:govet
+//nolint:govet,tagliatelle
 type StringSchema struct {
 	GenericSchema
-	Const     *string      `json:"const,omitempty"`
-	Enum      []string     `json:"enum,omitempty"`
-	MaxLength *uint64      `json:"maxLength,omitempty"`
-	MinLength *uint64      `json:"minLength,omitempty"`
-	Pattern   string       `json:"pattern,omitempty"`
-	Format    StringFormat `json:"format,omitempty"`
+	Const                    *string      `json:"const,omitempty"`
+	Enum                     []string     `json:"enum,omitempty"`
+	MarkdownEnumDescriptions []string     `json:"markdownEnumDescriptions,omitempty"`
+	MaxLength                *uint64      `json:"maxLength,omitempty"`
+	MinLength                *uint64      `json:"minLength,omitempty"`
+	Pattern                  string       `json:"pattern,omitempty"`
+	Format                   StringFormat `json:"format,omitempty"`
+	FormatMaximum            *string      `json:"formatMaximum,omitempty"`
+	FormatExclusiveMaximum   *string      `json:"formatExclusiveMaximum,omitempty"`
+	FormatMinimum            *string      `json:"formatMinimum,omitempty"`
+	FormatExclusiveMinimum   *string      `json:"formatExclusiveMinimum,omitempty"`
+	LessThanNow              bool         `json:"x-ltNow,omitempty"`
+	GreaterThanNow           bool         `json:"x-gtNow,omitempty"`
+	Within                   *string      `json:"x-within,omitempty"`
 }
 
 func NewStringSchema() *StringSchema {
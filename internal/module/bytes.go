@@ -0,0 +1,327 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+
+	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
+)
+
+const (
+	standardBase64Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	urlSafeBase64Alphabet  = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+)
+
+// base64Encoding is one of the two alphabets that protojson accepts when decoding bytes.
+type base64Encoding struct {
+	title     string
+	alphabet  string
+	charClass string
+}
+
+var base64Encodings = []base64Encoding{
+	{title: "Standard base64 encoding", alphabet: standardBase64Alphabet, charClass: `A-Za-z0-9+/`},
+	{title: "URL-safe base64 encoding", alphabet: urlSafeBase64Alphabet, charClass: `A-Za-z0-9_-`},
+}
+
+// byteLengthRange is an inclusive range of decoded byte lengths. A nil max means unbounded.
+type byteLengthRange struct {
+	max *uint64
+	min uint64
+}
+
+func (m *Module) schemaForBytes(rules *validate.BytesRules) jsonschema.Schema {
+	m.Debug("schemaForBytes")
+	lengths := m.byteLengthRanges(rules)
+
+	branches := make([]jsonschema.NonTrivialSchema, len(base64Encodings))
+	for i, encoding := range base64Encodings {
+		branches[i] = m.schemaForBase64Encoding(encoding, lengths, rules)
+	}
+
+	schema := jsonschema.NewStringSchema()
+	schema.AnyOf = branches
+	schemas := []jsonschema.NonTrivialSchema{schema}
+
+	if rules != nil {
+		if rules.Const != nil {
+			constant := jsonschema.NewStringSchema()
+			constant.Enum = base64Variants(rules.GetConst())
+			schemas = append(schemas, constant)
+		}
+
+		if len(rules.In) > 0 {
+			for _, value := range rules.In {
+				schema.Enum = append(schema.Enum, base64Variants(value)...)
+			}
+		}
+
+		if len(rules.NotIn) > 0 {
+			in := jsonschema.NewStringSchema()
+			for _, value := range rules.NotIn {
+				in.Enum = append(in.Enum, base64Variants(value)...)
+			}
+			schemas = append(schemas, jsonschema.Not(in))
+		}
+	}
+
+	return jsonschema.AllOf(schemas...)
+}
+
+func (m *Module) byteLengthRanges(rules *validate.BytesRules) []byteLengthRange {
+	m.Debug("byteLengthRanges")
+	bounds := byteLengthRange{}
+	if rules == nil {
+		return []byteLengthRange{bounds}
+	}
+
+	if rules.Len != nil {
+		bounds = byteLengthRange{min: rules.GetLen(), max: jsonschema.Size(rules.GetLen())}
+	}
+
+	if rules.MinLen != nil {
+		bounds.min = rules.GetMinLen()
+	}
+
+	if rules.MaxLen != nil {
+		bounds.max = jsonschema.Size(rules.GetMaxLen())
+	}
+
+	var fixed []uint64
+	switch rules.WellKnown.(type) {
+	case *validate.BytesRules_Ip:
+		if rules.GetIp() {
+			fixed = []uint64{4, 16}
+		}
+
+	case *validate.BytesRules_Ipv4:
+		if rules.GetIpv4() {
+			fixed = []uint64{4}
+		}
+
+	case *validate.BytesRules_Ipv6:
+		if rules.GetIpv6() {
+			fixed = []uint64{16}
+		}
+
+	case *validate.BytesRules_Uuid:
+		if rules.GetUuid() {
+			fixed = []uint64{16}
+		}
+	}
+
+	if fixed == nil {
+		return []byteLengthRange{bounds}
+	}
+
+	lengths := make([]byteLengthRange, 0, len(fixed))
+	for _, length := range fixed {
+		if length >= bounds.min && (bounds.max == nil || length <= *bounds.max) {
+			lengths = append(lengths, byteLengthRange{min: length, max: jsonschema.Size(length)})
+		}
+	}
+
+	return lengths
+}
+
+func (m *Module) schemaForBase64Encoding(encoding base64Encoding, lengths []byteLengthRange, rules *validate.BytesRules) jsonschema.NonTrivialSchema {
+	m.Debug("schemaForBase64Encoding")
+	schema := m.schemaForBase64Length(encoding, lengths)
+
+	var patterns []string
+	if rules != nil {
+		if len(rules.Prefix) > 0 {
+			patterns = append(patterns, "^"+base64CharClasses(encoding, rules.GetPrefix(), 0, false))
+		}
+
+		if len(rules.Suffix) > 0 {
+			patterns = append(patterns, base64UnalignedPattern(encoding, rules.GetSuffix(), true))
+		}
+
+		if len(rules.Contains) > 0 {
+			patterns = append(patterns, base64UnalignedPattern(encoding, rules.GetContains(), false))
+		}
+	}
+
+	if len(patterns) == 0 {
+		schema.Title = encoding.title
+		return schema
+	}
+
+	schemas := []jsonschema.NonTrivialSchema{schema}
+	for _, pattern := range patterns {
+		match := jsonschema.NewStringSchema()
+		match.Pattern = pattern
+		schemas = append(schemas, match)
+	}
+
+	return &jsonschema.GenericSchema{Title: encoding.title, AllOf: schemas}
+}
+
+// schemaForBase64Length matches the alphabet with patterns, and the decoded lengths with bounds on the encoded length.
+// There is one branch for unpadded values and one for each amount of padding, because the decoded length depends on it.
+// Line breaks (which the decoder ignores) are only allowed when the length is unbounded, because they would count
+// towards it.
+func (m *Module) schemaForBase64Length(encoding base64Encoding, lengths []byteLengthRange) *jsonschema.StringSchema {
+	m.Debug("schemaForBase64Length")
+	schema := jsonschema.NewStringSchema()
+	if len(lengths) == 1 && lengths[0].min == 0 && lengths[0].max == nil {
+		schema.Pattern = fmt.Sprintf(`^[\r\n%s]*={0,2}$`, encoding.charClass)
+		return schema
+	}
+
+	for _, length := range lengths {
+		unpadded := jsonschema.NewStringSchema()
+		unpadded.Pattern = fmt.Sprintf(`^[%s]*$`, encoding.charClass)
+		unpadded.MinLength = jsonschema.Size((length.min*4 + 2) / 3)
+		if length.max != nil {
+			unpadded.MaxLength = jsonschema.Size((*length.max*4 + 2) / 3)
+		}
+		schema.AnyOf = append(schema.AnyOf, unpadded)
+
+		for padding := uint64(1); padding <= 2; padding++ {
+			if padded := base64PaddedSchema(encoding, length, padding); padded != nil {
+				schema.AnyOf = append(schema.AnyOf, padded)
+			}
+		}
+	}
+
+	if len(schema.AnyOf) == 0 {
+		m.warnf("no value satisfies the length rules")
+		schema.Not = jsonschema.True
+	}
+
+	return schema
+}
+
+// base64PaddedSchema matches values with the given number of padding characters, which encode 3*n-padding bytes in
+// 4*n characters.
+func base64PaddedSchema(encoding base64Encoding, length byteLengthRange, padding uint64) *jsonschema.StringSchema {
+	minGroups := max((length.min+padding+2)/3, 1)
+	if length.max != nil && (*length.max+padding)/3 < minGroups {
+		return nil
+	}
+
+	schema := jsonschema.NewStringSchema()
+	schema.Pattern = fmt.Sprintf(`^(?:[%[1]s]{4})*[%[1]s]{%[2]d}%[3]s$`, encoding.charClass, 4-padding, strings.Repeat("=", int(padding))) //nolint:gosec
+	schema.MinLength = jsonschema.Size(minGroups * 4)
+	if length.max != nil {
+		schema.MaxLength = jsonschema.Size((*length.max + padding) / 3 * 4)
+	}
+
+	return schema
+}
+
+// base64UnalignedPattern has one alternative for each position the data can start at within a 3-byte group.
+func base64UnalignedPattern(encoding base64Encoding, data []byte, atEnd bool) string {
+	alternatives := make([]string, 3)
+	for offset := range alternatives {
+		alternative := base64CharClasses(encoding, data, offset, atEnd)
+		if atEnd {
+			switch (offset + len(data)) % 3 {
+			case 1:
+				alternative += "(?:==)?"
+			case 2:
+				alternative += "=?"
+			}
+		}
+		alternatives[offset] = alternative
+	}
+
+	pattern := fmt.Sprintf("^(?:[%s]{4})*(?:%s)", encoding.charClass, strings.Join(alternatives, "|"))
+	if atEnd {
+		pattern += "$"
+	}
+
+	return pattern
+}
+
+// base64CharClasses matches the encoding of data preceded by offset unknown bytes, starting at a 4-character boundary.
+// Characters that straddle unknown bits become character classes. If atEnd is set, the trailing bits are zero padding.
+func base64CharClasses(encoding base64Encoding, data []byte, offset int, atEnd bool) string {
+	const bitsPerChar = 6
+
+	totalBits := (offset + len(data)) * 8
+	known := func(bit int) (bool, bool) {
+		if bit < offset*8 {
+			return false, false
+		}
+
+		if bit >= totalBits {
+			return false, atEnd
+		}
+
+		i := bit - offset*8
+		return data[i/8]&(0x80>>(i%8)) != 0, true
+	}
+
+	var builder strings.Builder
+	for start := 0; start < totalBits; start += bitsPerChar {
+		var value, mask byte
+		for bit := range bitsPerChar {
+			value <<= 1
+			mask <<= 1
+			if set, ok := known(start + bit); ok {
+				mask |= 1
+				if set {
+					value |= 1
+				}
+			}
+		}
+
+		var chars []byte
+		for i := range len(encoding.alphabet) {
+			if byte(i)&mask == value {
+				chars = append(chars, encoding.alphabet[i])
+			}
+		}
+
+		switch len(chars) {
+		case 1:
+			builder.WriteString(regexp.QuoteMeta(string(chars)))
+		case len(encoding.alphabet):
+			builder.WriteString("[" + encoding.charClass + "]")
+		default:
+			builder.WriteString("[" + regexpCharClass(chars) + "]")
+		}
+	}
+
+	return builder.String()
+}
+
+func regexpCharClass(chars []byte) string {
+	var builder strings.Builder
+	for _, char := range chars {
+		if char == '-' {
+			builder.WriteString(`\-`)
+		} else {
+			builder.WriteByte(char)
+		}
+	}
+
+	return builder.String()
+}
+
+// base64Variants returns every spelling of the value that protojson accepts, starting with the one it produces.
+func base64Variants(value []byte) []string {
+	encodings := []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding}
+	variants := make([]string, 0, len(encodings))
+	seen := make(map[string]struct{}, len(encodings))
+
+	for _, encoding := range encodings {
+		variant := encoding.EncodeToString(value)
+		if _, ok := seen[variant]; !ok {
+			seen[variant] = struct{}{}
+			variants = append(variants, variant)
+		}
+	}
+
+	return variants
+}
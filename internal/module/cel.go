@@ -0,0 +1,47 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+
+	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
+)
+
+type celRules interface {
+	GetCel() []*validate.Rule
+	GetCelExpression() []string
+}
+
+func (m *Module) celRules(rules celRules) []jsonschema.CELRule {
+	m.Debug("celRules")
+	cel := make([]jsonschema.CELRule, 0, len(rules.GetCel())+len(rules.GetCelExpression()))
+	for _, rule := range rules.GetCel() {
+		cel = append(cel, jsonschema.CELRule{ID: rule.GetId(), Message: rule.GetMessage(), Expression: rule.GetExpression()})
+	}
+
+	for _, expression := range rules.GetCelExpression() {
+		cel = append(cel, jsonschema.CELRule{Expression: expression})
+	}
+
+	return cel
+}
+
+// describeCEL appends the messages of the rules to the description, so that editors show them alongside it.
+func describeCEL(description string, cel []jsonschema.CELRule) string {
+	paragraphs := []string{}
+	if description != "" {
+		paragraphs = append(paragraphs, description)
+	}
+
+	for _, rule := range cel {
+		if rule.Message != "" {
+			paragraphs = append(paragraphs, rule.Message)
+		}
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,772 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/parser"
+	pgs "github.com/lyft/protoc-gen-star/v2"
+
+	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
+)
+
+type celKind int
+
+const (
+	celStartsWith = "startsWith"
+	celEndsWith   = "endsWith"
+	celContains   = "contains"
+
+	celConstKeyword    = "const"
+	celRequiredKeyword = "required"
+)
+
+const (
+	celOther celKind = iota
+	celString
+	celBytes
+	celBool
+	celNumber
+	celQuotedNumber
+	celList
+	celMap
+)
+
+var (
+	celSizeKeywords = map[celKind]string{celString: "Length", celList: "Items", celMap: "Properties"}
+
+	celFlippedOperators = map[string]string{
+		operators.Equals:        operators.Equals,
+		operators.NotEquals:     operators.NotEquals,
+		operators.Less:          operators.Greater,
+		operators.LessEquals:    operators.GreaterEquals,
+		operators.Greater:       operators.Less,
+		operators.GreaterEquals: operators.LessEquals,
+	}
+
+	celNumberKeywords = map[string]string{
+		operators.Less:          "exclusiveMaximum",
+		operators.LessEquals:    "maximum",
+		operators.Greater:       "exclusiveMinimum",
+		operators.GreaterEquals: "minimum",
+	}
+)
+
+// celSchema is the translation of a CEL expression into schema keywords.
+// It is exact if it accepts precisely the documents that satisfy the expression, rather than a superset of them.
+type celSchema struct {
+	keywords map[string]any
+	exact    bool
+}
+
+// celTranslator translates the subset of CEL described in the README.
+// For message rules, this is the message; for field rules, it is the field's value.
+type celTranslator struct {
+	m       *Module
+	message pgs.Message
+	kind    celKind
+}
+
+// translateCEL returns schemas for the rules it understands, along with the rules that still need a CEL interpreter.
+// Rules that are only translated approximately are returned as well.
+func (m *Module) translateCEL(rules []jsonschema.CELRule, translator celTranslator) ([]jsonschema.NonTrivialSchema, []jsonschema.CELRule) {
+	m.Debug("translateCEL")
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	celParser, err := parser.NewParser(parser.Macros(parser.HasMacro))
+	if m.checkErr(err, "failed to create CEL parser") {
+		return nil, rules
+	}
+
+	var schemas []jsonschema.NonTrivialSchema
+	var untranslated []jsonschema.CELRule
+	for _, rule := range rules {
+		parsed, issues := celParser.Parse(common.NewTextSource(rule.Expression))
+		if len(issues.GetErrors()) > 0 {
+			untranslated = append(untranslated, rule)
+			continue
+		}
+
+		translation, ok := translator.rule(parsed.Expr())
+		if !ok {
+			untranslated = append(untranslated, rule)
+			continue
+		}
+
+		data, err := json.Marshal(translation.keywords)
+		if m.checkErr(err, "failed to marshal schema keywords") {
+			untranslated = append(untranslated, rule)
+			continue
+		}
+
+		schema, err := jsonschema.NewRawSchema(data)
+		if m.checkErr(err, "failed to create schema from CEL expression") {
+			untranslated = append(untranslated, rule)
+			continue
+		}
+		schemas = append(schemas, schema)
+
+		if !translation.exact {
+			untranslated = append(untranslated, rule)
+		}
+	}
+
+	return schemas, untranslated
+}
+
+func (m *Module) fieldCELTranslator(field pgs.Field) celTranslator {
+	switch {
+	case field.Type().IsMap():
+		return celTranslator{m: m, kind: celMap}
+	case field.Type().IsRepeated():
+		return celTranslator{m: m, kind: celList}
+	case field.Type().IsEmbed(), field.Type().IsEnum():
+		return celTranslator{m: m}
+	default:
+		return celTranslator{m: m, kind: celScalarKind(field.Type().ProtoType())}
+	}
+}
+
+func (m *Module) elementCELTranslator(element pgs.FieldTypeElem) celTranslator {
+	if element.IsEmbed() || element.IsEnum() {
+		return celTranslator{m: m}
+	}
+
+	return celTranslator{m: m, kind: celScalarKind(element.ProtoType())}
+}
+
+func celScalarKind(scalar pgs.ProtoType) celKind {
+	switch {
+	case scalar == pgs.Int64T, scalar == pgs.UInt64T, scalar == pgs.SInt64, scalar == pgs.Fixed64T, scalar == pgs.SFixed64:
+		return celQuotedNumber
+	case scalar.IsNumeric():
+		return celNumber
+	case scalar == pgs.StringT:
+		return celString
+	case scalar == pgs.BytesT:
+		return celBytes
+	case scalar == pgs.BoolT:
+		return celBool
+	default:
+		return celOther
+	}
+}
+
+// rule also accepts the protovalidate idiom of returning an empty string on success and a message on failure.
+func (t celTranslator) rule(expr ast.Expr) (celSchema, bool) {
+	if expr.Kind() == ast.CallKind && expr.AsCall().FunctionName() == operators.Conditional {
+		args := expr.AsCall().Args()
+		success, okSuccess := celStringLiteral(args[1])
+		failure, okFailure := celStringLiteral(args[2])
+		switch {
+		case okSuccess && okFailure && success == "" && failure != "":
+			return t.translate(args[0])
+		case okSuccess && okFailure && success != "" && failure == "":
+			return t.not(args[0])
+		}
+	}
+
+	return t.translate(expr)
+}
+
+func (t celTranslator) translate(expr ast.Expr) (celSchema, bool) {
+	switch expr.Kind() {
+	case ast.LiteralKind:
+		if value, ok := expr.AsLiteral().(types.Bool); ok {
+			if value {
+				return celSchema{keywords: map[string]any{}, exact: true}, true
+			}
+
+			return celSchema{keywords: celNot(map[string]any{}), exact: true}, true
+		}
+
+	case ast.SelectKind:
+		if expr.AsSelect().IsTestOnly() {
+			return t.presence(expr.AsSelect())
+		}
+
+	case ast.CallKind:
+		return t.call(expr.AsCall())
+
+	default:
+	}
+
+	return celSchema{}, false
+}
+
+func (t celTranslator) call(call ast.CallExpr) (celSchema, bool) {
+	args := call.Args()
+	switch call.FunctionName() {
+	case operators.LogicalAnd:
+		return t.allOf(celOperands(call, operators.LogicalAnd))
+
+	case operators.LogicalOr:
+		operands := celOperands(call, operators.LogicalOr)
+		if len(operands) == 2 && operands[0].Kind() == ast.CallKind && operands[0].AsCall().FunctionName() == operators.LogicalNot {
+			return t.implication(operands[0].AsCall().Args()[0], operands[1])
+		}
+
+		return t.anyOf(operands)
+
+	case operators.LogicalNot:
+		return t.not(args[0])
+
+	case operators.Conditional:
+		return t.conditional(args[0], args[1], args[2])
+
+	case operators.Equals, operators.NotEquals:
+		if celIsPresence(args[0]) && celIsPresence(args[1]) {
+			return t.presenceEquality(args[0].AsSelect(), args[1].AsSelect(), call.FunctionName() == operators.Equals)
+		}
+
+		return t.comparison(call.FunctionName(), args[0], args[1])
+
+	case operators.Less, operators.LessEquals, operators.Greater, operators.GreaterEquals:
+		return t.comparison(call.FunctionName(), args[0], args[1])
+
+	case operators.In:
+		return t.membership(args[0], args[1])
+
+	case celStartsWith, celEndsWith, celContains:
+		return t.stringFunction(call)
+
+	default:
+		return celSchema{}, false
+	}
+}
+
+func (t celTranslator) allOf(operands []ast.Expr) (celSchema, bool) {
+	result := celSchema{exact: true}
+	var schemas []map[string]any
+	for _, operand := range operands {
+		schema, ok := t.translate(operand)
+		if !ok {
+			return celSchema{}, false
+		}
+
+		result.exact = result.exact && schema.exact
+		if len(schema.keywords) > 0 {
+			schemas = append(schemas, schema.keywords)
+		}
+	}
+
+	switch len(schemas) {
+	case 0:
+		result.keywords = map[string]any{}
+	case 1:
+		result.keywords = schemas[0]
+	default:
+		result.keywords = map[string]any{"allOf": schemas}
+	}
+
+	return result, true
+}
+
+func (t celTranslator) anyOf(operands []ast.Expr) (celSchema, bool) {
+	result := celSchema{exact: true}
+	schemas := make([]map[string]any, len(operands))
+	for i, operand := range operands {
+		schema, ok := t.translate(operand)
+		if !ok {
+			return celSchema{}, false
+		}
+
+		result.exact = result.exact && schema.exact
+		schemas[i] = schema.keywords
+	}
+
+	result.keywords = celAnyOf(schemas...)
+	return result, true
+}
+
+// not requires an exact operand, because negating a superset of the valid documents would reject some of them.
+func (t celTranslator) not(operand ast.Expr) (celSchema, bool) {
+	schema, ok := t.translate(operand)
+	if !ok || !schema.exact {
+		return celSchema{}, false
+	}
+
+	return celSchema{keywords: celNot(schema.keywords), exact: true}, true
+}
+
+func (t celTranslator) implication(condition, consequence ast.Expr) (celSchema, bool) {
+	ifSchema, ok := t.translate(condition)
+	if !ok || !ifSchema.exact {
+		return celSchema{}, false
+	}
+
+	thenSchema, ok := t.translate(consequence)
+	if !ok {
+		return celSchema{}, false
+	}
+
+	dependent, okDependent := celRequiredOnly(ifSchema)
+	dependency, okDependency := celRequiredOnly(thenSchema)
+	if okDependent && okDependency {
+		return celSchema{keywords: t.dependentRequired(map[string][]string{dependent: {dependency}}), exact: true}, true
+	}
+
+	return celSchema{keywords: map[string]any{"if": ifSchema.keywords, "then": thenSchema.keywords}, exact: thenSchema.exact}, true
+}
+
+func (t celTranslator) conditional(condition, consequence, alternative ast.Expr) (celSchema, bool) {
+	ifSchema, ok := t.translate(condition)
+	if !ok || !ifSchema.exact {
+		return celSchema{}, false
+	}
+
+	thenSchema, ok := t.translate(consequence)
+	if !ok {
+		return celSchema{}, false
+	}
+
+	elseSchema, ok := t.translate(alternative)
+	if !ok {
+		return celSchema{}, false
+	}
+
+	keywords := map[string]any{"if": ifSchema.keywords}
+	if len(thenSchema.keywords) > 0 {
+		keywords["then"] = thenSchema.keywords
+	}
+
+	if len(elseSchema.keywords) > 0 {
+		keywords["else"] = elseSchema.keywords
+	}
+
+	return celSchema{keywords: keywords, exact: thenSchema.exact && elseSchema.exact}, true
+}
+
+func (t celTranslator) presenceEquality(left, right ast.SelectExpr, equal bool) (celSchema, bool) {
+	leftSchema, ok := t.presence(left)
+	if !ok {
+		return celSchema{}, false
+	}
+
+	rightSchema, ok := t.presence(right)
+	if !ok {
+		return celSchema{}, false
+	}
+
+	if !equal {
+		return celSchema{keywords: map[string]any{"oneOf": []map[string]any{leftSchema.keywords, rightSchema.keywords}}, exact: true}, true
+	}
+
+	leftName, okLeft := celRequiredOnly(leftSchema)
+	rightName, okRight := celRequiredOnly(rightSchema)
+	if okLeft && okRight {
+		return celSchema{keywords: t.dependentRequired(map[string][]string{leftName: {rightName}, rightName: {leftName}}), exact: true}, true
+	}
+
+	both := map[string]any{"allOf": []map[string]any{leftSchema.keywords, rightSchema.keywords}}
+	neither := celNot(celAnyOf(leftSchema.keywords, rightSchema.keywords))
+	return celSchema{keywords: celAnyOf(both, neither), exact: true}, true
+}
+
+func (t celTranslator) dependentRequired(dependencies map[string][]string) map[string]any {
+	if t.m.draft == jsonschema.Draft07 {
+		return map[string]any{"dependencies": dependencies}
+	}
+
+	return map[string]any{"dependentRequired": dependencies}
+}
+
+// presence translates has(this.field), which is only true for fields without explicit presence if they are not empty.
+func (t celTranslator) presence(selection ast.SelectExpr) (celSchema, bool) {
+	field, ok := t.field(selection.Operand(), selection.FieldName())
+	if !ok {
+		return celSchema{}, false
+	}
+
+	var value map[string]any
+	switch kind := t.m.fieldCELTranslator(field).kind; {
+	case kind == celList:
+		value = map[string]any{"minItems": 1}
+	case kind == celMap:
+		value = map[string]any{"minProperties": 1}
+	case t.m.hasPresence(field):
+	case kind == celString, kind == celBytes:
+		value = map[string]any{"minLength": 1}
+	case kind == celBool:
+		value = celConst(true)
+	default:
+		return celSchema{}, false
+	}
+
+	return celSchema{keywords: t.m.celRequiredProperty(field, value), exact: true}, true
+}
+
+func (t celTranslator) comparison(operator string, left, right ast.Expr) (celSchema, bool) {
+	if left.Kind() == ast.LiteralKind {
+		left, right, operator = right, left, celFlippedOperators[operator]
+	}
+
+	if subject, field, ok := t.sizeSubject(left); ok {
+		return t.sizeComparison(subject, field, operator, right)
+	}
+
+	kind, field, ok := t.subject(left)
+	if !ok {
+		return celSchema{}, false
+	}
+
+	value, operand, ok := celLiteral(right, kind)
+	if !ok {
+		return celSchema{}, false
+	}
+
+	var keywords map[string]any
+	switch operator {
+	case operators.Equals:
+		keywords = celEquals(value, kind)
+	case operators.NotEquals:
+		keywords = celNot(celEquals(value, kind))
+	default:
+		if !celIsNumber(kind) {
+			return celSchema{}, false
+		}
+
+		keywords = map[string]any{celNumberKeywords[operator]: value}
+	}
+
+	return t.constrain(field, keywords, celHolds(operator, celZero(kind), operand), !celIsNumber(kind)), true
+}
+
+func (t celTranslator) membership(left, right ast.Expr) (celSchema, bool) {
+	kind, field, ok := t.subject(left)
+	if !ok || right.Kind() != ast.ListKind {
+		return celSchema{}, false
+	}
+
+	elements := right.AsList().Elements()
+	values := make([]any, 0, len(elements))
+	holds := false
+	for _, element := range elements {
+		value, operand, ok := celLiteral(element, kind)
+		if !ok {
+			return celSchema{}, false
+		}
+
+		values = append(values, celValues(value, kind)...)
+		holds = holds || celHolds(operators.Equals, celZero(kind), operand)
+	}
+
+	return t.constrain(field, map[string]any{"enum": values}, holds, !celIsNumber(kind)), true
+}
+
+func (t celTranslator) stringFunction(call ast.CallExpr) (celSchema, bool) {
+	if !call.IsMemberFunction() || len(call.Args()) != 1 {
+		return celSchema{}, false
+	}
+
+	kind, field, ok := t.subject(call.Target())
+	if !ok || kind != celString {
+		return celSchema{}, false
+	}
+
+	value, ok := celStringLiteral(call.Args()[0])
+	if !ok {
+		return celSchema{}, false
+	}
+
+	pattern := regexp.QuoteMeta(value)
+	switch call.FunctionName() {
+	case celStartsWith:
+		pattern = "^" + pattern
+	case celEndsWith:
+		pattern += "$"
+	}
+
+	return t.constrain(field, map[string]any{"pattern": pattern}, value == "", true), true
+}
+
+func (t celTranslator) sizeComparison(kind celKind, field pgs.Field, operator string, right ast.Expr) (celSchema, bool) {
+	var size int64
+	switch literal := celLiteralValue(right).(type) {
+	case types.Int:
+		size = int64(literal)
+	case types.Uint:
+		size = int64(literal) //nolint:gosec
+	default:
+		return celSchema{}, false
+	}
+
+	minimum, maximum := "min"+celSizeKeywords[kind], "max"+celSizeKeywords[kind]
+	never := celNot(map[string]any{})
+
+	var keywords map[string]any
+	switch {
+	case operator == operators.Greater:
+		keywords = map[string]any{minimum: max(size+1, 0)}
+	case operator == operators.GreaterEquals:
+		keywords = map[string]any{minimum: max(size, 0)}
+	case operator == operators.Less && size <= 0, operator == operators.LessEquals && size < 0, operator == operators.Equals && size < 0:
+		keywords = never
+	case operator == operators.Less:
+		keywords = map[string]any{maximum: size - 1}
+	case operator == operators.LessEquals:
+		keywords = map[string]any{maximum: size}
+	case operator == operators.Equals:
+		keywords = map[string]any{minimum: size, maximum: size}
+	case operator == operators.NotEquals && size < 0:
+		keywords = map[string]any{}
+	case operator == operators.NotEquals:
+		keywords = celNot(map[string]any{minimum: size, maximum: size})
+	default:
+		return celSchema{}, false
+	}
+
+	return t.constrain(field, keywords, celHolds(operator, float64(0), float64(size)), true), true
+}
+
+// constrain applies keywords to the value of the subject. For message rules, the field must also be present unless its
+// default value satisfies the expression, since CEL sees the default value of an absent field.
+func (t celTranslator) constrain(field pgs.Field, keywords map[string]any, holdsForDefault, exact bool) celSchema {
+	if field == nil {
+		return celSchema{keywords: keywords, exact: exact}
+	}
+
+	if !holdsForDefault {
+		return celSchema{keywords: t.m.celRequiredProperty(field, keywords), exact: exact}
+	}
+
+	properties := make(map[string]any)
+	for _, name := range t.m.propertyNames(field) {
+		properties[name] = keywords
+	}
+
+	return celSchema{keywords: map[string]any{"properties": properties}, exact: exact}
+}
+
+// subject resolves this for field rules, or this.field for message rules.
+func (t celTranslator) subject(expr ast.Expr) (celKind, pgs.Field, bool) {
+	if t.message == nil {
+		return t.kind, nil, celIsThis(expr) && t.kind != celOther
+	}
+
+	if expr.Kind() != ast.SelectKind || expr.AsSelect().IsTestOnly() {
+		return celOther, nil, false
+	}
+
+	field, ok := t.field(expr.AsSelect().Operand(), expr.AsSelect().FieldName())
+	if !ok {
+		return celOther, nil, false
+	}
+
+	kind := t.m.fieldCELTranslator(field).kind
+	return kind, field, kind != celOther
+}
+
+// sizeSubject resolves size(subject) and subject.size() for strings, lists and maps.
+func (t celTranslator) sizeSubject(expr ast.Expr) (celKind, pgs.Field, bool) {
+	if expr.Kind() != ast.CallKind || expr.AsCall().FunctionName() != "size" {
+		return celOther, nil, false
+	}
+
+	call := expr.AsCall()
+	var operand ast.Expr
+	switch {
+	case call.IsMemberFunction() && len(call.Args()) == 0:
+		operand = call.Target()
+	case !call.IsMemberFunction() && len(call.Args()) == 1:
+		operand = call.Args()[0]
+	default:
+		return celOther, nil, false
+	}
+
+	kind, field, ok := t.subject(operand)
+	if _, sized := celSizeKeywords[kind]; !ok || !sized {
+		return celOther, nil, false
+	}
+
+	return kind, field, true
+}
+
+func (t celTranslator) field(operand ast.Expr, name string) (pgs.Field, bool) {
+	if t.message == nil || !celIsThis(operand) {
+		return nil, false
+	}
+
+	for _, field := range t.message.Fields() {
+		if field.Name().String() == name {
+			return field, !t.m.fieldOptions(field).GetHidden()
+		}
+	}
+
+	return nil, false
+}
+
+// celRequiredProperty matches objects that set the field, using any of its property names, to a value matching the keywords.
+func (m *Module) celRequiredProperty(field pgs.Field, keywords map[string]any) map[string]any {
+	names := m.propertyNames(field)
+	alternatives := make([]map[string]any, len(names))
+	for i, name := range names {
+		alternative := map[string]any{celRequiredKeyword: []string{name}}
+		if keywords != nil {
+			alternative["properties"] = map[string]any{name: keywords}
+		}
+		alternatives[i] = alternative
+	}
+
+	if len(alternatives) == 1 {
+		return alternatives[0]
+	}
+
+	return celAnyOf(alternatives...)
+}
+
+// celRequiredOnly returns the property name if the schema does nothing but require it, so that it can be expressed as a dependency.
+func celRequiredOnly(schema celSchema) (string, bool) {
+	if len(schema.keywords) != 1 {
+		return "", false
+	}
+
+	required, ok := schema.keywords[celRequiredKeyword].([]string)
+	if !ok || len(required) != 1 {
+		return "", false
+	}
+
+	return required[0], true
+}
+
+func celNot(keywords map[string]any) map[string]any {
+	return map[string]any{"not": keywords}
+}
+
+func celAnyOf(schemas ...map[string]any) map[string]any {
+	return map[string]any{"anyOf": schemas}
+}
+
+func celConst(value any) map[string]any {
+	return map[string]any{celConstKeyword: value}
+}
+
+// celEquals matches the value in any of the forms that protojson accepts for the kind.
+func celEquals(value any, kind celKind) map[string]any {
+	if values := celValues(value, kind); len(values) > 1 {
+		return map[string]any{"enum": values}
+	}
+
+	return celConst(value)
+}
+
+// celValues returns the JSON forms of a literal. Numbers of 64 bits are written as strings by protojson, which accepts
+// them either way.
+func celValues(value any, kind celKind) []any {
+	if kind != celQuotedNumber {
+		return []any{value}
+	}
+
+	switch number := value.(type) {
+	case int64:
+		return []any{value, strconv.FormatInt(number, 10)}
+	case uint64:
+		return []any{value, strconv.FormatUint(number, 10)}
+	case float64:
+		return []any{value, strconv.FormatFloat(number, 'f', -1, 64)}
+	default:
+		return []any{value}
+	}
+}
+
+func celIsNumber(kind celKind) bool {
+	return kind == celNumber || kind == celQuotedNumber
+}
+
+func celOperands(call ast.CallExpr, operator string) []ast.Expr {
+	var operands []ast.Expr
+	for _, arg := range call.Args() {
+		if arg.Kind() == ast.CallKind && arg.AsCall().FunctionName() == operator {
+			operands = append(operands, celOperands(arg.AsCall(), operator)...)
+		} else {
+			operands = append(operands, arg)
+		}
+	}
+
+	return operands
+}
+
+func celIsThis(expr ast.Expr) bool {
+	return expr.Kind() == ast.IdentKind && expr.AsIdent() == "this"
+}
+
+func celIsPresence(expr ast.Expr) bool {
+	return expr.Kind() == ast.SelectKind && expr.AsSelect().IsTestOnly()
+}
+
+func celLiteralValue(expr ast.Expr) any {
+	if expr.Kind() != ast.LiteralKind {
+		return nil
+	}
+
+	return expr.AsLiteral()
+}
+
+func celStringLiteral(expr ast.Expr) (string, bool) {
+	value, ok := celLiteralValue(expr).(types.String)
+	return string(value), ok
+}
+
+// celLiteral returns the JSON value of a literal of the given kind, and the value to compare it with the default value.
+func celLiteral(expr ast.Expr, kind celKind) (any, any, bool) {
+	switch literal := celLiteralValue(expr).(type) {
+	case types.String:
+		return string(literal), string(literal), kind == celString
+	case types.Bool:
+		return bool(literal), bool(literal), kind == celBool
+	case types.Int:
+		return int64(literal), float64(literal), celIsNumber(kind)
+	case types.Uint:
+		return uint64(literal), float64(literal), celIsNumber(kind)
+	case types.Double:
+		return float64(literal), float64(literal), celIsNumber(kind)
+	default:
+		return nil, nil, false
+	}
+}
+
+func celZero(kind celKind) any {
+	switch kind {
+	case celString:
+		return ""
+	case celBool:
+		return false
+	default:
+		return float64(0)
+	}
+}
+
+// celHolds evaluates a comparison of values of the same type, as returned by celLiteral and celZero.
+func celHolds(operator string, value, literal any) bool {
+	switch operator {
+	case operators.Equals:
+		return value == literal
+	case operators.NotEquals:
+		return value != literal
+	}
+
+	left, _ := value.(float64)
+	right, _ := literal.(float64)
+	switch operator {
+	case operators.Less:
+		return left < right
+	case operators.LessEquals:
+		return left <= right
+	case operators.Greater:
+		return left > right
+	case operators.GreaterEquals:
+		return left >= right
+	default:
+		return false
+	}
+}
@@ -56,13 +56,26 @@ func (m *Module) schemaForRepeated(item pgs.FieldTypeElem, rules *validate.Repea
 
 func (m *Module) schemaForElement(element pgs.FieldTypeElem, rules *validate.FieldRules) jsonschema.Schema {
 	m.Debug("schemaForElement")
-	if element.IsEmbed() {
-		return m.schemaForEmbed(element.Embed(), rules)
+	var schema jsonschema.Schema
+	switch {
+	case element.IsEmbed():
+		schema = m.schemaForEmbed(element.Embed(), rules)
+	case element.IsEnum():
+		schema = m.schemaForEnum(element.Enum(), rules.GetEnum(), false)
+	default:
+		schema = m.schemaForScalar(element.ProtoType(), rules)
 	}
 
-	if element.IsEnum() {
-		return m.schemaForEnum(element.Enum(), rules.GetEnum())
+	if cel := m.celRules(rules); len(cel) > 0 {
+		if documented, ok := schema.(jsonschema.NonTrivialSchema); ok {
+			constraints, untranslated := m.translateCEL(cel, m.elementCELTranslator(element))
+			documented = jsonschema.AllOf(append([]jsonschema.NonTrivialSchema{documented}, constraints...)...)
+			documented.Document("", describeCEL("", cel))
+			documented.Annotate(jsonschema.Annotations{CEL: untranslated})
+			m.isolateRef(documented)
+			schema = documented
+		}
 	}
 
-	return m.schemaForScalar(element.ProtoType(), rules)
+	return schema
 }
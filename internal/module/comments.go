@@ -0,0 +1,102 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"slices"
+	"strings"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Field numbers in the descriptor protos, which make up the paths of source code locations.
+const (
+	fileMessageTypePath      = 4
+	fileExtensionPath        = 7
+	messageNestedTypePath    = 3
+	messageExtensionTypePath = 6
+)
+
+type documentedEntity interface {
+	SourceCodeInfo() pgs.SourceCodeInfo
+}
+
+func (m *Module) comments(entity documentedEntity) string {
+	m.Debug("comments")
+	var comments []string
+	if info := entity.SourceCodeInfo(); info != nil {
+		comments = []string{info.LeadingComments(), info.TrailingComments()}
+	} else if extension, ok := entity.(pgs.Extension); ok {
+		// pgs does not attach source code info to extensions.
+		if location := extensionLocation(extension); location != nil {
+			comments = []string{location.GetLeadingComments(), location.GetTrailingComments()}
+		}
+	}
+
+	var paragraphs []string
+	for _, comment := range comments {
+		if paragraph := normalizeComment(comment); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func normalizeComment(comment string) string {
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(strings.TrimPrefix(line, " "), " \t\r")
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func extensionLocation(extension pgs.Extension) *descriptorpb.SourceCodeInfo_Location {
+	path := entityPath(extension.DefinedIn())
+	switch parent := extension.DefinedIn().(type) {
+	case pgs.File:
+		path = append(path, fileExtensionPath, pathIndex(parent.Descriptor().GetExtension(), extension.Descriptor()))
+	case pgs.Message:
+		path = append(path, messageExtensionTypePath, pathIndex(parent.Descriptor().GetExtension(), extension.Descriptor()))
+	default:
+		return nil
+	}
+
+	for _, location := range extension.File().Descriptor().GetSourceCodeInfo().GetLocation() {
+		if slices.Equal(location.GetPath(), path) {
+			return location
+		}
+	}
+
+	return nil
+}
+
+// entityPath returns the path of a file or message, in the form used by source code locations.
+func entityPath(entity pgs.ParentEntity) []int32 {
+	message, ok := entity.(pgs.Message)
+	if !ok {
+		return nil
+	}
+
+	switch parent := message.Parent().(type) {
+	case pgs.File:
+		return []int32{fileMessageTypePath, pathIndex(parent.Descriptor().GetMessageType(), message.Descriptor())}
+	case pgs.Message:
+		return append(entityPath(parent), messageNestedTypePath, pathIndex(parent.Descriptor().GetNestedType(), message.Descriptor()))
+	default:
+		return nil
+	}
+}
+
+func pathIndex[T comparable](items []T, item T) int32 {
+	for i, candidate := range items {
+		if candidate == item {
+			return int32(i)
+		}
+	}
+
+	return -1
+}
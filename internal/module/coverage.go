@@ -0,0 +1,286 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
+)
+
+const (
+	fieldRulesName protoreflect.FullName = "buf.validate.FieldRules"
+
+	// timestampRangeReason explains why the timestamp range rules are only surfaced through extension keywords.
+	timestampRangeReason = "only annotated; JSON Schema cannot compare date-times"
+)
+
+//nolint:goconst
+var (
+	numericRuleNames = []string{"const", "lt", "lte", "gt", "gte", "in", "not_in"}
+
+	// supportedRules lists the rules that are translated into schema keywords.
+	supportedRules = newRuleSet(map[string][]string{
+		"FieldRules":     {"required"},
+		"OneofRules":     {"required"},
+		"AnyRules":       {"in", "not_in"},
+		"BoolRules":      {"const"},
+		"BytesRules":     {"const", "len", "min_len", "max_len", "prefix", "suffix", "contains", "in", "not_in", "ip", "ipv4", "ipv6", "uuid"},
+		"DoubleRules":    numericRuleNames,
+		"DurationRules":  numericRuleNames,
+		"EnumRules":      {"const", "defined_only", "in", "not_in"},
+		"Fixed32Rules":   numericRuleNames,
+		"Fixed64Rules":   numericRuleNames,
+		"FieldMaskRules": {"const", "in", "not_in"},
+		"FloatRules":     numericRuleNames,
+		"Int32Rules":     numericRuleNames,
+		"Int64Rules":     numericRuleNames,
+		"MapRules":       {"min_pairs", "max_pairs", "keys", "values"},
+		"RepeatedRules":  {"min_items", "max_items", "unique", "items"},
+		"SFixed32Rules":  numericRuleNames,
+		"SFixed64Rules":  numericRuleNames,
+		"SInt32Rules":    numericRuleNames,
+		"SInt64Rules":    numericRuleNames,
+		"TimestampRules": {"const"},
+		"UInt32Rules":    numericRuleNames,
+		"UInt64Rules":    numericRuleNames,
+		"StringRules": {
+			"const", "len", "min_len", "max_len", "pattern", "prefix", "suffix", "contains", "not_contains", "in", "not_in",
+			"email", "hostname", "ip", "ipv4", "ipv6", "uri", "uri_ref", "address", "uuid", "tuuid",
+			"ip_with_prefixlen", "ipv4_with_prefixlen", "ipv6_with_prefixlen", "host_and_port", "ulid", "protobuf_fqn", "protobuf_dot_fqn", "well_known_regex", "strict",
+		},
+	})
+
+	// partlySupportedRules are translated into schema keywords that accept some values the rule rejects.
+	partlySupportedRules = map[protoreflect.FullName]string{
+		"buf.validate.StringRules.ip_prefix":   "host bits are not checked",
+		"buf.validate.StringRules.ipv4_prefix": "host bits are not checked",
+		"buf.validate.StringRules.ipv6_prefix": "host bits are not checked",
+		"buf.validate.TimestampRules.lt":       timestampRangeReason,
+		"buf.validate.TimestampRules.lte":      timestampRangeReason,
+		"buf.validate.TimestampRules.lt_now":   timestampRangeReason,
+		"buf.validate.TimestampRules.gt":       timestampRangeReason,
+		"buf.validate.TimestampRules.gte":      timestampRangeReason,
+		"buf.validate.TimestampRules.gt_now":   timestampRangeReason,
+		"buf.validate.TimestampRules.within":   timestampRangeReason,
+	}
+
+	// celRuleFields are checked by untranslatedCELRules, because it depends on the expression whether they are translated.
+	celRuleFields = newRuleSet(map[string][]string{
+		"FieldRules":   {"cel", "cel_expression"},
+		"MessageRules": {"cel", "cel_expression"},
+	})
+
+	// annotationRules document values rather than constrain them, so there is nothing to translate.
+	annotationRules = map[protoreflect.Name]struct{}{"example": {}}
+)
+
+type ruleSet map[protoreflect.FullName]struct{}
+
+func newRuleSet(rules map[string][]string) ruleSet {
+	set := make(ruleSet)
+	for message, fields := range rules {
+		for _, field := range fields {
+			set[protoreflect.FullName(fmt.Sprintf("buf.validate.%s.%s", message, field))] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+// unsupportedRule is a populated rule that is not translated, or only partly translated if there is a reason.
+type unsupportedRule struct {
+	path   string
+	reason string
+}
+
+type coverageReport map[string]*messageCoverage
+
+type messageCoverage struct {
+	Fields map[string][]string `json:"fields,omitempty"`
+	Rules  []string            `json:"rules,omitempty"`
+}
+
+// checkCoverage warns about every rule of the messages that the schemas do not express.
+func (m *Module) checkCoverage(files []pgs.File) coverageReport {
+	report := make(coverageReport)
+	for _, file := range files {
+		m.Push(fmt.Sprintf("file:%s", file.Name()))
+
+		for _, message := range file.AllMessages() {
+			report[message.FullyQualifiedName()[1:]] = m.checkMessageCoverage(message)
+		}
+
+		m.Pop()
+	}
+
+	return report
+}
+
+func (m *Module) checkMessageCoverage(message pgs.Message) *messageCoverage {
+	m.Push(fmt.Sprintf("message:%s", message.Name()))
+	defer m.Pop()
+	m.Debug("checkMessageCoverage")
+
+	coverage := &messageCoverage{}
+	if m.overrideSchema(m.messageOptions(message)) != nil {
+		return coverage
+	}
+
+	messageRules := m.messageRules(message)
+	unsupported := m.unsupportedRules(messageRules.ProtoReflect(), "", false)
+	unsupported = append(unsupported, m.untranslatedCELRules(messageRules, celTranslator{m: m, message: message}, "")...)
+
+	for _, oneOf := range message.RealOneOfs() {
+		rules := &validate.OneofRules{}
+		_, err := oneOf.Extension(validate.E_Oneof, rules)
+		m.checkErr(err, "unable to read oneOf option")
+		unsupported = append(unsupported, m.unsupportedRules(rules.ProtoReflect(), string(oneOf.Name())+".", false)...)
+	}
+
+	coverage.Rules = m.warnUnsupportedRules(unsupported)
+
+	for _, field := range messageFields(message) {
+		options := m.fieldOptions(field)
+		if options.GetHidden() || options.GetOverride() != "" {
+			continue
+		}
+
+		m.Push(fmt.Sprintf("field:%s", fieldName(field)))
+		if rules := m.fieldUnsupportedRules(field); len(rules) > 0 {
+			if coverage.Fields == nil {
+				coverage.Fields = make(map[string][]string)
+			}
+			coverage.Fields[fieldName(field)] = m.warnUnsupportedRules(rules)
+		}
+		m.Pop()
+	}
+
+	return coverage
+}
+
+func (m *Module) fieldUnsupportedRules(field pgs.Field) []unsupportedRule {
+	rules := m.fieldRules(field)
+	unsupported := m.unsupportedRules(rules.ProtoReflect(), "", false)
+	unsupported = append(unsupported, m.untranslatedCELRules(rules, m.fieldCELTranslator(field), "")...)
+
+	switch {
+	case field.Type().IsMap():
+		element := m.elementCELTranslator(field.Type().Element())
+		unsupported = append(unsupported, m.untranslatedCELRules(rules.GetMap().GetValues(), element, "map.values.")...)
+	case field.Type().IsRepeated():
+		element := m.elementCELTranslator(field.Type().Element())
+		unsupported = append(unsupported, m.untranslatedCELRules(rules.GetRepeated().GetItems(), element, "repeated.items.")...)
+	}
+
+	return unsupported
+}
+
+// untranslatedCELRules lists the CEL rules that are not translated exactly, identified by their ID or expression.
+func (m *Module) untranslatedCELRules(rules celRules, translator celTranslator, prefix string) []unsupportedRule {
+	_, untranslated := m.translateCEL(m.celRules(rules), translator)
+	if len(untranslated) == 0 {
+		return nil
+	}
+
+	remaining := make(map[jsonschema.CELRule]struct{}, len(untranslated))
+	for _, rule := range untranslated {
+		remaining[rule] = struct{}{}
+	}
+
+	var unsupported []unsupportedRule
+	for _, rule := range rules.GetCel() {
+		if _, ok := remaining[jsonschema.CELRule{ID: rule.GetId(), Message: rule.GetMessage(), Expression: rule.GetExpression()}]; ok {
+			label := rule.GetId()
+			if label == "" {
+				label = rule.GetExpression()
+			}
+			unsupported = append(unsupported, unsupportedRule{path: fmt.Sprintf("%scel[%s]", prefix, label)})
+		}
+	}
+
+	for _, expression := range rules.GetCelExpression() {
+		if _, ok := remaining[jsonschema.CELRule{Expression: expression}]; ok {
+			unsupported = append(unsupported, unsupportedRule{path: fmt.Sprintf("%scel_expression[%s]", prefix, expression)})
+		}
+	}
+
+	return unsupported
+}
+
+// warnUnsupportedRules warns about each rule and returns their paths for the coverage report.
+func (m *Module) warnUnsupportedRules(rules []unsupportedRule) []string {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	paths := make([]string, len(rules))
+	for i, rule := range rules {
+		if rule.reason != "" {
+			m.warnf("rule %s is only partly supported: %s", rule.path, rule.reason)
+		} else {
+			m.warnf("rule %s is not supported", rule.path)
+		}
+		paths[i] = rule.path
+	}
+
+	return paths
+}
+
+// unsupportedRules lists the populated rules that are not translated, with paths relative to the rules message.
+// Map keys are object property names, so only their string rules apply.
+func (m *Module) unsupportedRules(rules protoreflect.Message, prefix string, keys bool) []unsupportedRule {
+	var unsupported []unsupportedRule
+	rules.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		path := prefix + string(field.Name())
+		_, supported := supportedRules[field.FullName()]
+		_, annotation := annotationRules[field.Name()]
+		_, cel := celRuleFields[field.FullName()]
+
+		switch {
+		case annotation, m.ignoresOnlyPresence(rules, field, value):
+		case field.Message() != nil && field.Message().FullName() == fieldRulesName:
+			unsupported = append(unsupported, m.unsupportedRules(value.Message(), path+".", field.Name() == "keys")...)
+		case keys && field.Name() != "string":
+			unsupported = append(unsupported, unsupportedRule{path: path})
+		case cel:
+		case field.ContainingOneof() != nil && field.Message() != nil && field.ContainingMessage().FullName() == fieldRulesName:
+			unsupported = append(unsupported, m.unsupportedRules(value.Message(), path+".", false)...)
+		case !supported:
+			unsupported = append(unsupported, unsupportedRule{path: path, reason: partlySupportedRules[field.FullName()]})
+		}
+
+		return true
+	})
+
+	return unsupported
+}
+
+// ignoresOnlyPresence reports whether the field rule is ignore = IGNORE_IF_ZERO_VALUE without any type rules.
+// The only effect is then to make the field optional, which schemaForField takes care of.
+func (m *Module) ignoresOnlyPresence(rules protoreflect.Message, field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+	if field.FullName() != fieldRulesName+".ignore" || value.Enum() != protoreflect.EnumNumber(validate.Ignore_IGNORE_IF_ZERO_VALUE) {
+		return false
+	}
+
+	return rules.WhichOneof(rules.Descriptor().Oneofs().ByName("type")) == nil
+}
+
+func (m *Module) reportCoverage(filename string, report coverageReport) {
+	var content strings.Builder
+	encoder := json.NewEncoder(&content)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if m.checkErr(encoder.Encode(report), "failed to marshal coverage report") {
+		return
+	}
+
+	m.addFile(filename, content.String())
+}
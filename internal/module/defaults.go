@@ -0,0 +1,135 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+)
+
+// fieldDefault converts the default value set on a field into the form that protojson uses, as JSON.
+func (m *Module) fieldDefault(field pgs.Field) *string {
+	m.Debug("fieldDefault")
+	if field.Descriptor().DefaultValue == nil {
+		return nil
+	}
+
+	value := field.Descriptor().GetDefaultValue()
+	var result any
+	switch {
+	case field.Type().IsEnum():
+		result = value
+		if m.enums == enumsNumbers {
+			values := m.lookUpEnumValuesByName(field.Type().Enum(), value)
+			if len(values) == 0 {
+				return nil
+			}
+			result = values[0].Value()
+		}
+
+	default:
+		switch field.Type().ProtoType() {
+		case pgs.StringT:
+			result = value
+
+		case pgs.BytesT:
+			data, ok := unescapeBytes(value)
+			if !ok {
+				m.errorf("invalid default value %q", value)
+				return nil
+			}
+			result = base64.StdEncoding.EncodeToString(data)
+
+		case pgs.Int64T, pgs.SInt64, pgs.SFixed64, pgs.UInt64T, pgs.Fixed64T:
+			result = value
+
+		case pgs.FloatT, pgs.DoubleT:
+			// protojson writes inf and nan as strings, which the number schema would reject, so they are left out.
+			if value == "inf" || value == "-inf" || value == "nan" {
+				return nil
+			}
+			result = json.RawMessage(value)
+
+		default:
+			result = json.RawMessage(value)
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		m.errorf("invalid default value %q", value)
+		return nil
+	}
+
+	content := string(data)
+	return &content
+}
+
+func (m *Module) lookUpEnumValuesByName(enum pgs.Enum, name string) []pgs.EnumValue {
+	for _, value := range enum.Values() {
+		if value.Name().String() == name {
+			return m.lookUpEnumValues(enum, value.Value())
+		}
+	}
+
+	m.errorf("unknown enum value %s", name)
+	return nil
+}
+
+// unescapeBytes decodes the C escape sequences that descriptors use for default values of bytes fields.
+func unescapeBytes(value string) ([]byte, bool) {
+	escapes := map[byte]byte{'a': '\a', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v', '\\': '\\', '\'': '\'', '"': '"', '?': '?'}
+
+	data := make([]byte, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			data = append(data, value[i])
+			continue
+		}
+
+		i++
+		if i == len(value) {
+			return nil, false
+		}
+
+		if c, ok := escapes[value[i]]; ok {
+			data = append(data, c)
+			continue
+		}
+
+		base, digits, start := 8, 3, i
+		if value[i] == 'x' {
+			base, digits, start = 16, 2, i+1
+		}
+
+		end := start
+		for end < len(value) && end-start < digits && isDigit(value[end], base) {
+			end++
+		}
+
+		c, err := strconv.ParseUint(value[start:end], base, 8)
+		if err != nil {
+			return nil, false
+		}
+
+		data = append(data, byte(c))
+		i = end - 1
+	}
+
+	return data, true
+}
+
+func isDigit(c byte, base int) bool {
+	switch {
+	case c >= '0' && c <= '7':
+		return true
+	case base == 8:
+		return false
+	default:
+		return c == '8' || c == '9' || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+	}
+}
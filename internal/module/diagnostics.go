@@ -0,0 +1,102 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"fmt"
+	"strings"
+)
+
+type severity string
+
+const (
+	severityError   severity = "error"
+	severityWarning severity = "warning"
+)
+
+type diagnostic struct {
+	severity severity
+	location string
+	message  string
+}
+
+func (d diagnostic) String() string {
+	if d.location == "" {
+		return fmt.Sprintf("%s: %s", d.severity, d.message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", d.severity, d.location, d.message)
+}
+
+// Push enters a context, so that diagnostics can say where they were raised.
+func (m *Module) Push(prefix string) {
+	m.location = append(m.location, prefix)
+}
+
+func (m *Module) Pop() {
+	m.location = m.location[:len(m.location)-1]
+}
+
+// Debug traces the builder, prefixed with its context like pgs does.
+func (m *Module) Debug(v ...any) {
+	if m.debugger == nil {
+		return
+	}
+
+	var prefix strings.Builder
+	for _, location := range m.location {
+		fmt.Fprintf(&prefix, "[%s] ", location)
+	}
+
+	m.debugger.Debug(prefix.String() + fmt.Sprint(v...))
+}
+
+// errorf records a problem that makes the schema inaccurate, and carries on generating.
+func (m *Module) errorf(format string, args ...any) {
+	m.diagnose(severityError, format, args...)
+}
+
+// warnf records a problem that the schema works around, which is only fatal in strict mode.
+func (m *Module) warnf(format string, args ...any) {
+	m.diagnose(severityWarning, format, args...)
+}
+
+// checkErr records an error if err is set, and reports whether it was.
+func (m *Module) checkErr(err error, message string) bool {
+	if err == nil {
+		return false
+	}
+
+	m.errorf("%s: %v", message, err)
+	return true
+}
+
+func (m *Module) diagnose(severity severity, format string, args ...any) {
+	m.diagnostics = append(m.diagnostics, diagnostic{
+		severity: severity,
+		location: strings.Join(m.location, " "),
+		message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// reportDiagnostics returns the errors, and the warnings in strict mode, which fail the generation. Other warnings are logged.
+func (m *Module) reportDiagnostics(log func(...any)) []string {
+	var errs []string
+	reported := make(map[diagnostic]struct{}, len(m.diagnostics))
+	for _, d := range m.diagnostics {
+		if _, ok := reported[d]; ok {
+			continue
+		}
+		reported[d] = struct{}{}
+
+		if d.severity == severityError || m.strict {
+			errs = append(errs, d.String())
+		} else {
+			log(d.String())
+		}
+	}
+
+	m.diagnostics = nil
+	return errs
+}
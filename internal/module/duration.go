@@ -0,0 +1,237 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	duration "google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	fractionPattern        = `(?:\.\d{1,9})?`
+	unsignedDecimalPattern = `(?:0|[1-9]\d*)` + fractionPattern
+)
+
+type comparison int
+
+const (
+	lessThan comparison = iota
+	lessThanOrEqual
+	greaterThanOrEqual
+	greaterThan
+)
+
+func (c comparison) inclusive() bool {
+	return c == lessThanOrEqual || c == greaterThanOrEqual
+}
+
+func (c comparison) lower() bool {
+	return c == greaterThanOrEqual || c == greaterThan
+}
+
+// durationMagnitude is the absolute value of a duration, split into its integer seconds and 9-digit nanoseconds.
+type durationMagnitude struct {
+	seconds string
+	nanos   string
+}
+
+func (d durationMagnitude) isZero() bool {
+	return d.seconds == "0" && strings.Trim(d.nanos, "0") == ""
+}
+
+// durationRangePattern matches protojson duration strings whose value compares to the bound as requested.
+// Negative strings are handled by comparing their magnitude against the negated bound.
+func durationRangePattern(bound *duration.Duration, c comparison) string {
+	seconds, nanos := bound.GetSeconds(), bound.GetNanos()
+	negative := seconds < 0 || nanos < 0
+	if seconds < 0 {
+		seconds = -seconds
+	}
+	if nanos < 0 {
+		nanos = -nanos
+	}
+
+	magnitude := durationMagnitude{seconds: strconv.FormatInt(seconds, 10), nanos: fmt.Sprintf("%09d", nanos)}
+
+	// Flipping the sign of a value reverses the comparison against the flipped bound.
+	flipped := map[comparison]comparison{
+		lessThan:           greaterThan,
+		lessThanOrEqual:    greaterThanOrEqual,
+		greaterThanOrEqual: lessThanOrEqual,
+		greaterThan:        lessThan,
+	}
+
+	var alternatives []string
+	if positive := magnitudePattern(magnitude, c, negative); positive != "" {
+		alternatives = append(alternatives, positive)
+	}
+
+	if negativePattern := magnitudePattern(magnitude, flipped[c], !negative); negativePattern != "" {
+		alternatives = append(alternatives, "-"+negativePattern)
+	}
+
+	return "^(?:" + strings.Join(alternatives, "|") + ")s$"
+}
+
+// magnitudePattern matches the unsigned durations x for which x compares to the bound as requested.
+// If boundNegative is set, the bound lies on the other side of zero from x, so every x (or none) matches.
+func magnitudePattern(bound durationMagnitude, c comparison, boundNegative bool) string {
+	if boundNegative && !bound.isZero() {
+		if c.lower() {
+			return unsignedDecimalPattern
+		}
+		return ""
+	}
+
+	var alternatives []string
+	if c.lower() {
+		if integers := integerGreaterThanPattern(bound.seconds); integers != "" {
+			alternatives = append(alternatives, integers+fractionPattern)
+		}
+	} else if integers := integerLessThanPattern(bound.seconds); integers != "" {
+		alternatives = append(alternatives, integers+fractionPattern)
+	}
+
+	if fraction, ok := nanosPattern(bound.nanos, c); ok {
+		alternatives = append(alternatives, bound.seconds+fraction)
+	}
+
+	return joinAlternatives(alternatives)
+}
+
+// integerGreaterThanPattern matches integers without leading zeros that are greater than n.
+func integerGreaterThanPattern(n string) string {
+	alternatives := []string{`[1-9]\d+`}
+	if len(n) > 1 {
+		alternatives[0] = fmt.Sprintf(`[1-9]\d{%d,}`, len(n))
+	}
+
+	for i := range len(n) {
+		if n[i] < '9' {
+			alternatives = append(alternatives, n[:i]+digitRange(n[i]+1, '9')+repeatDigits(len(n)-i-1))
+		}
+	}
+
+	return joinAlternatives(alternatives)
+}
+
+// integerLessThanPattern matches integers without leading zeros that are less than n.
+func integerLessThanPattern(n string) string {
+	if n == "0" {
+		return ""
+	}
+
+	alternatives := []string{"0"}
+	if len(n) > 2 {
+		alternatives = append(alternatives, fmt.Sprintf(`[1-9]\d{0,%d}`, len(n)-2))
+	} else if len(n) == 2 {
+		alternatives = append(alternatives, `[1-9]`)
+	}
+
+	for i := range len(n) {
+		lowest := byte('0')
+		if i == 0 {
+			lowest = '1'
+		}
+
+		if n[i] > lowest {
+			alternatives = append(alternatives, n[:i]+digitRange(lowest, n[i]-1)+repeatDigits(len(n)-i-1))
+		}
+	}
+
+	return joinAlternatives(alternatives)
+}
+
+// nanosPattern matches the optional fractional part of a duration whose nanoseconds compare to nanos (9 digits) as requested.
+// The boolean result is false if no fraction matches.
+func nanosPattern(nanos string, c comparison) (string, bool) {
+	// A missing fraction, or one consisting only of zeros, is equivalent to zero nanoseconds.
+	zero := strings.Trim(nanos, "0") == ""
+	allowMissing := (zero && c.inclusive()) || (!zero && !c.lower())
+
+	var alternatives []string
+	if c.inclusive() {
+		if trimmed := strings.TrimRight(nanos, "0"); trimmed == "" {
+			alternatives = append(alternatives, `0{1,9}`)
+		} else if len(trimmed) < len(nanos) {
+			alternatives = append(alternatives, fmt.Sprintf("%s0{0,%d}", trimmed, len(nanos)-len(trimmed)))
+		} else {
+			alternatives = append(alternatives, trimmed)
+		}
+	}
+
+	if c.lower() {
+		for i := range len(nanos) {
+			if nanos[i] < '9' {
+				alternatives = append(alternatives, nanos[:i]+digitRange(nanos[i]+1, '9')+optionalDigits(len(nanos)-i-1))
+			}
+		}
+	} else {
+		last := strings.LastIndexFunc(nanos, func(r rune) bool { return r != '0' })
+		for i := 0; i <= last; i++ {
+			if i > 0 {
+				alternatives = append(alternatives, nanos[:i])
+			}
+
+			if nanos[i] > '0' {
+				alternatives = append(alternatives, nanos[:i]+digitRange('0', nanos[i]-1)+optionalDigits(len(nanos)-i-1))
+			}
+		}
+	}
+
+	switch {
+	case len(alternatives) == 0 && allowMissing:
+		return "", true
+	case len(alternatives) == 0:
+		return "", false
+	case allowMissing:
+		return `(?:\.` + joinAlternatives(alternatives) + `)?`, true
+	default:
+		return `\.` + joinAlternatives(alternatives), true
+	}
+}
+
+func digitRange(from, to byte) string {
+	switch {
+	case from == to:
+		return string(from)
+	case from == '0' && to == '9':
+		return `\d`
+	default:
+		return fmt.Sprintf("[%c-%c]", from, to)
+	}
+}
+
+func repeatDigits(n int) string {
+	switch n {
+	case 0:
+		return ""
+	case 1:
+		return `\d`
+	default:
+		return fmt.Sprintf(`\d{%d}`, n)
+	}
+}
+
+func optionalDigits(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`\d{0,%d}`, n)
+}
+
+func joinAlternatives(alternatives []string) string {
+	switch len(alternatives) {
+	case 0:
+		return ""
+	case 1:
+		return alternatives[0]
+	default:
+		return "(?:" + strings.Join(alternatives, "|") + ")"
+	}
+}
@@ -4,84 +4,195 @@
 package module
 
 import (
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+
 	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	pgs "github.com/lyft/protoc-gen-star/v2"
 
 	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
 )
 
-func (m *Module) defineEnum(enum pgs.Enum) *jsonschema.StringSchema {
-	schema := jsonschema.NewStringSchema()
+func (m *Module) defineEnum(enum pgs.Enum) jsonschema.NonTrivialSchema {
+	m.Debug("defineEnum")
+	options := m.enumOptions(enum)
+	if override := m.overrideSchema(options); override != nil {
+		return override
+	}
 
-	for _, value := range enum.Values() {
-		schema.Enum = append(schema.Enum, value.Name().String())
+	schema := m.schemaForEnumValues(m.visibleEnumValues(enum), false)
+	description := m.comments(enum)
+	if t, ok := m.googleType(enum); ok {
+		description = t.description
 	}
 
-	return schema
+	schema.Document(enum.Name().String(), description)
+
+	deprecated := options.GetDeprecated() || enum.Descriptor().GetOptions().GetDeprecated()
+	return m.customiseSchema(schema, options, jsonschema.Annotations{Deprecated: deprecated}, nil)
 }
 
-func (m *Module) schemaForEnum(enum pgs.Enum, rules *validate.EnumRules) jsonschema.Schema {
+// schemaForEnum excludes the zero value if nonZero is set, because protovalidate treats it as unset.
+func (m *Module) schemaForEnum(enum pgs.Enum, rules *validate.EnumRules, nonZero bool) jsonschema.Schema {
 	m.Debug("schemaForEnum")
+	exclude := make(map[int32]struct{})
+	if nonZero {
+		exclude[0] = struct{}{}
+	}
+
 	if rules != nil {
 		switch {
 		case rules.Const != nil:
 			return m.schemaForEnumConst(enum, rules.GetConst())
 		case len(rules.In) > 0:
-			return m.schemaForEnumIn(enum, rules.In)
-		case len(rules.NotIn) > 0:
-			return m.schemaForEnumNotIn(enum, rules.NotIn)
+			return m.schemaForEnumIn(enum, rules.In, exclude)
 		}
+
+		for _, value := range rules.NotIn {
+			exclude[value] = struct{}{}
+		}
+	}
+
+	if len(exclude) == 0 {
+		return m.withUndefinedEnumNumbers(enum, rules, m.enumRef(enum), exclude)
 	}
 
-	return m.enumRef(enum)
+	return m.schemaForEnumNotIn(enum, rules, exclude)
 }
 
-func (m *Module) schemaForEnumConst(enum pgs.Enum, value int32) *jsonschema.StringSchema {
+func (m *Module) schemaForEnumConst(enum pgs.Enum, value int32) jsonschema.NonTrivialSchema {
 	m.Debug("schemaForEnumConst")
-	schema := jsonschema.NewStringSchema()
-	schema.Const = jsonschema.String(m.lookUpEnumName(enum, value))
-
-	return schema
+	return m.schemaForEnumValues(m.lookUpEnumValues(enum, value), true)
 }
 
-func (m *Module) schemaForEnumIn(enum pgs.Enum, values []int32) *jsonschema.StringSchema {
+func (m *Module) schemaForEnumIn(enum pgs.Enum, values []int32, exclude map[int32]struct{}) jsonschema.NonTrivialSchema {
 	m.Debug("schemaForEnumIn")
-	schema := jsonschema.NewStringSchema()
+	var enumValues []pgs.EnumValue
 	for _, value := range values {
-		schema.Enum = append(schema.Enum, m.lookUpEnumName(enum, value))
+		if _, ok := exclude[value]; !ok {
+			exclude[value] = struct{}{}
+			enumValues = append(enumValues, m.lookUpEnumValues(enum, value)...)
+		}
 	}
 
-	return schema
+	return m.schemaForEnumValues(enumValues, false)
 }
 
-func (m *Module) schemaForEnumNotIn(enum pgs.Enum, values []int32) *jsonschema.StringSchema {
+func (m *Module) schemaForEnumNotIn(enum pgs.Enum, rules *validate.EnumRules, exclude map[int32]struct{}) jsonschema.NonTrivialSchema {
 	m.Debug("schemaForEnumNotIn")
-	exclude := make(map[int32]struct{}, len(values))
-	for _, v := range values {
-		exclude[v] = struct{}{}
+	var enumValues []pgs.EnumValue
+	for _, v := range m.visibleEnumValues(enum) {
+		if _, ok := exclude[v.Value()]; !ok {
+			enumValues = append(enumValues, v)
+		}
 	}
 
-	schema := jsonschema.NewStringSchema()
-	for _, v := range enum.Values() {
-		value := v.Value()
-		if _, ok := exclude[value]; !ok {
-			schema.Enum = append(schema.Enum, v.Name().String())
+	return m.withUndefinedEnumNumbers(enum, rules, m.schemaForEnumValues(enumValues, false), exclude)
+}
+
+// withUndefinedEnumNumbers also accepts numbers without a name, which protojson allows for open enums unless defined_only is set.
+func (m *Module) withUndefinedEnumNumbers(enum pgs.Enum, rules *validate.EnumRules, schema jsonschema.NonTrivialSchema, exclude map[int32]struct{}) jsonschema.NonTrivialSchema {
+	m.Debug("withUndefinedEnumNumbers")
+	if m.enums == enumsNames || rules.GetDefinedOnly() || !m.isOpenEnum(enum) {
+		return schema
+	}
+
+	number := jsonschema.NewIntegerSchema()
+	number.Minimum = jsonschema.Number(strconv.Itoa(math.MinInt32))
+	number.Maximum = jsonschema.Number(strconv.Itoa(math.MaxInt32))
+
+	if len(exclude) > 0 {
+		excluded := jsonschema.NewIntegerSchema()
+		for _, value := range slices.Sorted(maps.Keys(exclude)) {
+			excluded.Enum = append(excluded.Enum, jsonschema.Number(strconv.FormatInt(int64(value), 10)))
+		}
+		number.Not = excluded
+	}
+
+	return jsonschema.AnyOf(schema, number)
+}
+
+func (m *Module) visibleEnumValues(enum pgs.Enum) []pgs.EnumValue {
+	if !m.enumOptions(enum).GetHideDeprecatedValues() {
+		return enum.Values()
+	}
+
+	var values []pgs.EnumValue
+	for _, value := range enum.Values() {
+		if !value.Descriptor().GetOptions().GetDeprecated() {
+			values = append(values, value)
 		}
 	}
 
-	return schema
+	return values
 }
 
-func (m *Module) lookUpEnumName(enum pgs.Enum, value int32) string {
-	m.Debug("lookUpEnumName")
+// schemaForEnumValues accepts the given values in the forms selected by the enums parameter.
+func (m *Module) schemaForEnumValues(values []pgs.EnumValue, constant bool) jsonschema.NonTrivialSchema {
+	m.Debug("schemaForEnumValues")
+	var schemas []jsonschema.NonTrivialSchema
+
+	if m.enums != enumsNumbers {
+		schema := jsonschema.NewStringSchema()
+		documented := false
+		descriptions := make([]string, len(values))
+		for i, value := range values {
+			schema.Enum = append(schema.Enum, value.Name().String())
+			descriptions[i] = m.comments(value)
+			documented = documented || descriptions[i] != ""
+		}
+
+		if documented {
+			schema.MarkdownEnumDescriptions = descriptions
+		}
+
+		if constant && len(schema.Enum) == 1 {
+			schema.Const = jsonschema.String(schema.Enum[0])
+			schema.Enum = nil
+			schema.MarkdownEnumDescriptions = nil
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	if m.enums != enumsNames {
+		schema := jsonschema.NewIntegerSchema()
+		seen := make(map[int32]struct{}, len(values))
+		for _, value := range values {
+			if _, ok := seen[value.Value()]; !ok {
+				seen[value.Value()] = struct{}{}
+				schema.Enum = append(schema.Enum, jsonschema.Number(strconv.FormatInt(int64(value.Value()), 10)))
+			}
+		}
+
+		if constant && len(schema.Enum) == 1 {
+			schema.Const = schema.Enum[0]
+			schema.Enum = nil
+		}
+
+		schemas = append(schemas, schema)
+	}
+
+	return jsonschema.OneOf(schemas...)
+}
+
+// lookUpEnumValues returns every name of the value, since enums that allow aliases can have several.
+func (m *Module) lookUpEnumValues(enum pgs.Enum, value int32) []pgs.EnumValue {
+	m.Debug("lookUpEnumValues")
+	var values []pgs.EnumValue
 	for _, enumValue := range enum.Values() {
 		if enumValue.Value() == value {
-			return enumValue.Name().String()
+			values = append(values, enumValue)
 		}
 	}
 
-	m.Failf("unknown enum value %d", value)
-	return ""
+	if len(values) == 0 {
+		m.errorf("unknown enum value %d", value)
+	}
+
+	return values
 }
 
 func (m *Module) enumRef(enum pgs.Enum) *jsonschema.GenericSchema {
@@ -0,0 +1,180 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"strings"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const syntaxEditions pgs.Syntax = "editions"
+
+// MinimumEdition and MaximumEdition bound the editions that the module understands the features of.
+const (
+	MinimumEdition = descriptorpb.Edition_EDITION_PROTO2
+	MaximumEdition = descriptorpb.Edition_EDITION_2023
+)
+
+// editionDefaults holds the defaults of the features that affect protojson, for each edition that the plugin supports.
+var editionDefaults = map[descriptorpb.Edition]*descriptorpb.FeatureSet{
+	descriptorpb.Edition_EDITION_PROTO2: {
+		FieldPresence: descriptorpb.FeatureSet_EXPLICIT.Enum(),
+		EnumType:      descriptorpb.FeatureSet_CLOSED.Enum(),
+	},
+	descriptorpb.Edition_EDITION_PROTO3: {
+		FieldPresence: descriptorpb.FeatureSet_IMPLICIT.Enum(),
+		EnumType:      descriptorpb.FeatureSet_OPEN.Enum(),
+	},
+	descriptorpb.Edition_EDITION_2023: {
+		FieldPresence: descriptorpb.FeatureSet_EXPLICIT.Enum(),
+		EnumType:      descriptorpb.FeatureSet_OPEN.Enum(),
+	},
+}
+
+// fieldPresence resolves how the field tracks presence. Files that use editions set it with features rather than labels,
+// and proto2 required fields have legacy required presence.
+func (m *Module) fieldPresence(field pgs.Field) descriptorpb.FeatureSet_FieldPresence {
+	switch {
+	case field.Type().IsRepeated(), field.Type().IsMap():
+		return descriptorpb.FeatureSet_IMPLICIT
+	case isExtension(field):
+		return descriptorpb.FeatureSet_EXPLICIT
+	case field.Syntax() != syntaxEditions:
+		switch {
+		case field.Descriptor().GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED:
+			return descriptorpb.FeatureSet_LEGACY_REQUIRED
+		case field.HasPresence():
+			return descriptorpb.FeatureSet_EXPLICIT
+		default:
+			return descriptorpb.FeatureSet_IMPLICIT
+		}
+	case field.Type().IsEmbed(), field.InOneOf():
+		// Only scalar fields outside oneofs can have implicit presence, and the feature is inherited regardless.
+		if m.fieldFeatures(field).GetFieldPresence() == descriptorpb.FeatureSet_LEGACY_REQUIRED {
+			return descriptorpb.FeatureSet_LEGACY_REQUIRED
+		}
+		return descriptorpb.FeatureSet_EXPLICIT
+	default:
+		return m.fieldFeatures(field).GetFieldPresence()
+	}
+}
+
+// hasPresence is the edition-aware equivalent of field.HasPresence.
+func (m *Module) hasPresence(field pgs.Field) bool {
+	return m.fieldPresence(field) != descriptorpb.FeatureSet_IMPLICIT
+}
+
+// hasOptionalKeyword reports whether the field is optional in the sense of the proto3 optional keyword, which editions
+// express by explicit presence on a scalar field.
+func (m *Module) hasOptionalKeyword(field pgs.Field) bool {
+	switch {
+	case isExtension(field):
+		// pgs cannot resolve the syntax of extensions from the field methods, but they are all optional or repeated.
+		return field.Descriptor().GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	case field.Syntax() != syntaxEditions:
+		return field.HasOptionalKeyword()
+	default:
+		return !field.Type().IsEmbed() && !field.InOneOf() && m.fieldPresence(field) == descriptorpb.FeatureSet_EXPLICIT
+	}
+}
+
+// isGroupLike reports whether protojson names the field after its message type when using proto names, as it does for
+// groups and the delimited fields of editions that look like them.
+func (m *Module) isGroupLike(field pgs.Field) bool {
+	embed := fieldMessage(field)
+	if embed == nil || m.fieldFeatures(field).GetMessageEncoding() != descriptorpb.FeatureSet_DELIMITED {
+		return false
+	}
+
+	return strings.ToLower(embed.Name().String()) == field.Name().String() &&
+		embed.File().Name() == field.File().Name() &&
+		embed.Parent().FullyQualifiedName() == fieldParent(field).FullyQualifiedName()
+}
+
+// fieldMessage returns the message type of a singular or repeated message field.
+func fieldMessage(field pgs.Field) pgs.Message {
+	switch {
+	case field.Type().IsEmbed():
+		return field.Type().Embed()
+	case field.Type().IsRepeated() && field.Type().Element().IsEmbed():
+		return field.Type().Element().Embed()
+	default:
+		return nil
+	}
+}
+
+// fieldParent returns the message that declares the field, or the scope that declares an extension.
+func fieldParent(field pgs.Field) pgs.ParentEntity {
+	if extension, ok := field.(pgs.Extension); ok {
+		return extension.DefinedIn()
+	}
+
+	return field.Message()
+}
+
+func isExtension(field pgs.Field) bool {
+	_, ok := field.(pgs.Extension)
+	return ok
+}
+
+// isOpenEnum reports whether protojson accepts numbers that the enum does not define.
+func (m *Module) isOpenEnum(enum pgs.Enum) bool {
+	return m.enumFeatures(enum).GetEnumType() == descriptorpb.FeatureSet_OPEN
+}
+
+func (m *Module) fieldFeatures(field pgs.Field) *descriptorpb.FeatureSet {
+	features := m.parentFeatures(fieldParent(field))
+	if field.InOneOf() {
+		features = mergeFeatures(features, field.OneOf().Descriptor().GetOptions().GetFeatures())
+	}
+
+	return mergeFeatures(features, field.Descriptor().GetOptions().GetFeatures())
+}
+
+func (m *Module) enumFeatures(enum pgs.Enum) *descriptorpb.FeatureSet {
+	return mergeFeatures(m.parentFeatures(enum.Parent()), enum.Descriptor().GetOptions().GetFeatures())
+}
+
+// parentFeatures resolves features from the edition defaults down through the file and any enclosing messages.
+func (m *Module) parentFeatures(parent pgs.ParentEntity) *descriptorpb.FeatureSet {
+	switch entity := parent.(type) {
+	case pgs.Message:
+		return mergeFeatures(m.parentFeatures(entity.Parent()), entity.Descriptor().GetOptions().GetFeatures())
+
+	case pgs.File:
+		edition := descriptorpb.Edition_EDITION_PROTO2
+		switch entity.Syntax() {
+		case pgs.Proto3:
+			edition = descriptorpb.Edition_EDITION_PROTO3
+		case syntaxEditions:
+			edition = entity.Descriptor().GetEdition()
+		default:
+		}
+
+		defaults, ok := editionDefaults[edition]
+		if !ok {
+			m.errorf("unsupported edition %s", edition)
+			defaults = editionDefaults[MaximumEdition]
+		}
+
+		return mergeFeatures(defaults, entity.Descriptor().GetOptions().GetFeatures())
+
+	default:
+		m.errorf("unexpected parent entity %T", parent)
+		return editionDefaults[MaximumEdition]
+	}
+}
+
+func mergeFeatures(features, overrides *descriptorpb.FeatureSet) *descriptorpb.FeatureSet {
+	if overrides == nil {
+		return features
+	}
+
+	merged := proto.CloneOf(features)
+	proto.Merge(merged, overrides)
+	return merged
+}
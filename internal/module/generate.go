@@ -0,0 +1,116 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Generate converts the files of a request into schemas without a protoc plugin process, so failures are returned instead
+// of exiting. The files that could be generated are returned alongside any diagnostics that failed the request. Warnings
+// are written to logs.
+func Generate(req *pluginpb.CodeGeneratorRequest, logs io.Writer) ([]*pluginpb.CodeGeneratorResponse_File, error) {
+	req = proto.CloneOf(req)
+	PrepareRequest(req)
+	ast, err := buildAST(logs, func(debugger pgs.Debugger) pgs.AST {
+		return pgs.ProcessCodeGeneratorRequest(debugger, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := newModule(pgs.ParseParameters(req.GetParameter()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	files := m.generate(ast.Targets())
+	return files, diagnosticsError(m.reportDiagnostics(logger(logs)))
+}
+
+// buildAST lets pgs process the descriptors. pgs reports descriptors it cannot process through the debugger, which
+// normally exits, so the errorDebugger turns them into errors instead.
+func buildAST(logs io.Writer, process func(pgs.Debugger) pgs.AST) (ast pgs.AST, err error) {
+	defer recoverFailure(&err)
+	return process(&errorDebugger{logs: logs}), nil
+}
+
+// diagnosticsError joins the diagnostics that failed the generation.
+func diagnosticsError(errs []string) error {
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// logger writes each message to logs on a line of its own.
+func logger(logs io.Writer) func(...any) {
+	return func(v ...any) {
+		fmt.Fprintln(logs, v...)
+	}
+}
+
+// recoverFailure returns a failure raised by the errorDebugger as the error, and lets any other panic through.
+func recoverFailure(err *error) {
+	if r := recover(); r != nil {
+		f, ok := r.(failure)
+		if !ok {
+			panic(r)
+		}
+		*err = f
+	}
+}
+
+type failure string
+
+func (f failure) Error() string {
+	return string(f)
+}
+
+// errorDebugger turns failures into panics that buildAST recovers from.
+type errorDebugger struct {
+	logs   io.Writer
+	parent *errorDebugger
+	prefix string
+}
+
+func (d *errorDebugger) Log(v ...any)                  { fmt.Fprintln(d.logs, d.prefix+fmt.Sprint(v...)) }
+func (d *errorDebugger) Logf(format string, v ...any)  { d.Log(fmt.Sprintf(format, v...)) }
+func (d *errorDebugger) Debug(...any)                  {}
+func (d *errorDebugger) Debugf(string, ...any)         {}
+func (d *errorDebugger) Fail(v ...any)                 { panic(failure(d.prefix + fmt.Sprint(v...))) }
+func (d *errorDebugger) Failf(format string, v ...any) { d.Fail(fmt.Sprintf(format, v...)) }
+func (d *errorDebugger) Exit(code int)                 { d.Failf("exited with code %d", code) }
+
+func (d *errorDebugger) CheckErr(err error, v ...any) {
+	if err != nil {
+		d.Failf("%s: %s", fmt.Sprint(v...), err.Error())
+	}
+}
+
+func (d *errorDebugger) Assert(expr bool, v ...any) {
+	if !expr {
+		d.Fail(v...)
+	}
+}
+
+func (d *errorDebugger) Push(prefix string) pgs.Debugger {
+	return &errorDebugger{logs: d.logs, parent: d, prefix: fmt.Sprintf("%s[%s] ", d.prefix, prefix)}
+}
+
+func (d *errorDebugger) Pop() pgs.Debugger {
+	if d.parent == nil {
+		return d
+	}
+
+	return d.parent
+}
@@ -0,0 +1,112 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+)
+
+// googleType describes one of the common types in google.type, which protojson writes like any other message or enum.
+// The rules express the constraints that the type documents on its fields, so that they are described like those set
+// with protovalidate.
+type googleType struct {
+	rules       map[string]*validate.FieldRules
+	description string
+}
+
+var googleTypes = map[string]googleType{
+	".google.type.Color": {
+		description: "A color in the RGBA color space. Each component is in the range 0 to 1, and alpha defaults to 1 (solid) if unset.",
+		rules: map[string]*validate.FieldRules{
+			"red":   unitIntervalRules(),
+			"green": unitIntervalRules(),
+			"blue":  unitIntervalRules(),
+			"alpha": unitIntervalRules(),
+		},
+	},
+	".google.type.Date": {
+		description: "A whole or partial calendar date. Month and day are 0 for a year on its own, and day is 0 for a year and month. Year is 0 for a month and day without a year.",
+		rules: map[string]*validate.FieldRules{
+			"year":  int32RangeRules(0, 9999),
+			"month": int32RangeRules(0, 12),
+			"day":   int32RangeRules(0, 31),
+		},
+	},
+	".google.type.DayOfWeek": {
+		description: "A day of the week.",
+	},
+	".google.type.Decimal": {
+		description: "A decimal number, written with an optional sign, digits, an optional decimal point and an optional exponent.",
+		rules: map[string]*validate.FieldRules{
+			"value": {Type: &validate.FieldRules_String_{String_: &validate.StringRules{
+				Pattern: proto.String(`^[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][+-]?[0-9]+)?$`),
+			}}},
+		},
+	},
+	".google.type.Interval": {
+		description: "A time interval, from the start time (inclusive) to the end time (exclusive). Both are unbounded if unset.",
+	},
+	".google.type.LatLng": {
+		description: "A latitude and longitude pair in degrees, using the WGS84 standard.",
+		rules: map[string]*validate.FieldRules{
+			"latitude":  doubleRangeRules(-90, 90),
+			"longitude": doubleRangeRules(-180, 180),
+		},
+	},
+	".google.type.Money": {
+		description: "An amount of money with its ISO 4217 currency code. Units and nanos must have the same sign.",
+		rules: map[string]*validate.FieldRules{
+			"currency_code": {Type: &validate.FieldRules_String_{String_: &validate.StringRules{Pattern: proto.String("^[A-Z]{3}$")}}},
+			"nanos":         int32RangeRules(-999_999_999, 999_999_999),
+		},
+	},
+	".google.type.PostalAddress": {
+		description: "A postal address, for postal delivery or payments. The CLDR region code is required.",
+		rules: map[string]*validate.FieldRules{
+			"revision":    {Type: &validate.FieldRules_Int32{Int32: &validate.Int32Rules{Const: proto.Int32(0)}}},
+			"region_code": {Required: proto.Bool(true), Type: &validate.FieldRules_String_{String_: &validate.StringRules{Pattern: proto.String("^[A-Z]{2}$")}}},
+		},
+	},
+	".google.type.TimeOfDay": {
+		description: "A time of day, independent of any date or time zone. Hours may be 24 for the end of the day, and seconds may be 60 for leap seconds.",
+		rules: map[string]*validate.FieldRules{
+			"hours":   int32RangeRules(0, 24),
+			"minutes": int32RangeRules(0, 59),
+			"seconds": int32RangeRules(0, 60),
+			"nanos":   int32RangeRules(0, 999_999_999),
+		},
+	},
+}
+
+// googleType looks up the built-in description of the entity, unless the google_types parameter turns them off.
+func (m *Module) googleType(entity namedEntity) (googleType, bool) {
+	if !m.googleTypes {
+		return googleType{}, false
+	}
+
+	t, ok := googleTypes[entity.FullyQualifiedName()]
+	return t, ok
+}
+
+func int32RangeRules(minimum, maximum int32) *validate.FieldRules {
+	return &validate.FieldRules{Type: &validate.FieldRules_Int32{Int32: &validate.Int32Rules{
+		GreaterThan: &validate.Int32Rules_Gte{Gte: minimum},
+		LessThan:    &validate.Int32Rules_Lte{Lte: maximum},
+	}}}
+}
+
+func doubleRangeRules(minimum, maximum float64) *validate.FieldRules {
+	return &validate.FieldRules{Type: &validate.FieldRules_Double{Double: &validate.DoubleRules{
+		GreaterThan: &validate.DoubleRules_Gte{Gte: minimum},
+		LessThan:    &validate.DoubleRules_Lte{Lte: maximum},
+	}}}
+}
+
+func unitIntervalRules() *validate.FieldRules {
+	return &validate.FieldRules{Type: &validate.FieldRules_Float{Float: &validate.FloatRules{
+		GreaterThan: &validate.FloatRules_Gte{Gte: 0},
+		LessThan:    &validate.FloatRules_Lte{Lte: 1},
+	}}}
+}
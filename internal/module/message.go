@@ -5,9 +5,13 @@ package module
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
 )
@@ -16,59 +20,157 @@ func (m *Module) defineMessage(message pgs.Message) jsonschema.NonTrivialSchema
 	m.pushMessage(message)
 	m.Debug("defineMessage")
 
+	options := m.messageOptions(message)
+	if override := m.overrideSchema(options); override != nil {
+		m.popMessage(message, override)
+		return override
+	}
+
 	schema := jsonschema.NewObjectSchema()
 	schema.AdditionalProperties = jsonschema.False
 	schemas := []jsonschema.NonTrivialSchema{schema}
 
-	for _, field := range message.Fields() {
-		name := m.propertyName(field)
+	for _, field := range messageFields(message) {
+		if m.fieldOptions(field).GetHidden() {
+			if m.fieldRules(field).GetRequired() {
+				m.warnf("field %s is hidden but required, so no document can be valid", fieldName(field))
+			}
+			continue
+		}
+
+		names := m.propertyNames(field)
 		valueSchema, required := m.schemaForField(field)
-		schema.Properties[name] = valueSchema
-		if required {
-			schema.Required = append(schema.Required, name)
+		for _, name := range names {
+			schema.Properties[name] = valueSchema
+		}
+
+		switch {
+		case len(names) == 1 && required:
+			schema.Required = append(schema.Required, names[0])
+		case len(names) > 1 && required:
+			schemas = append(schemas, m.schemaForPresence(field))
+		case len(names) > 1:
+			// protojson rejects documents that spell the same field both ways.
+			both := jsonschema.NewObjectSchema()
+			both.Required = names
+			schemas = append(schemas, jsonschema.Not(both))
 		}
 	}
 
-	for _, oneOf := range message.OneOfs() {
+	for _, oneOf := range message.RealOneOfs() {
 		oneOfSchema := m.schemaForOneOf(oneOf)
 		if oneOfSchema != nil {
 			schemas = append(schemas, oneOfSchema)
 		}
 	}
 
+	cel := m.celRules(m.messageRules(message))
+	constraints, untranslated := m.translateCEL(cel, celTranslator{m: m, message: message})
+	schemas = append(schemas, constraints...)
+
+	description := m.comments(message)
+	if t, ok := m.googleType(message); ok {
+		description = t.description
+	}
+
 	result := jsonschema.AllOf(schemas...)
+	result.Document(message.Name().String(), describeCEL(description, cel))
+	deprecated := options.GetDeprecated() || message.Descriptor().GetOptions().GetDeprecated()
+	annotations := m.annotations(options.GetExamples(), options.Default, deprecated)
+	annotations.CEL = untranslated
+	result = m.customiseSchema(result, options, annotations, nil)
 	m.popMessage(message, result)
 	return result
 }
 
-func (m *Module) propertyName(field pgs.Field) string {
-	return field.Descriptor().GetJsonName()
+// messageFields returns the fields of the message, followed by the extensions of it that are declared anywhere in the
+// request.
+func messageFields(message pgs.Message) []pgs.Field {
+	extensions := slices.Clone(message.Extensions())
+	slices.SortFunc(extensions, func(a, b pgs.Extension) int {
+		return strings.Compare(a.FullyQualifiedName(), b.FullyQualifiedName())
+	})
+
+	fields := slices.Clone(message.Fields())
+	for _, extension := range extensions {
+		fields = append(fields, extension)
+	}
+
+	return fields
+}
+
+// fieldName identifies the field in diagnostics. Extensions are identified by their fully-qualified names.
+func fieldName(field pgs.Field) string {
+	if isExtension(field) {
+		return strings.TrimPrefix(field.FullyQualifiedName(), ".")
+	}
+
+	return field.Name().String()
+}
+
+func (m *Module) propertyNames(field pgs.Field) []string {
+	// protojson always writes extensions by their fully-qualified names in brackets.
+	if isExtension(field) {
+		return []string{fmt.Sprintf("[%s]", fieldName(field))}
+	}
+
+	jsonName, protoName := field.Descriptor().GetJsonName(), field.Name().String()
+	if m.isGroupLike(field) {
+		protoName = fieldMessage(field).Name().String()
+	}
+
+	switch {
+	case m.fieldNames == fieldNamesProto:
+		return []string{protoName}
+	case m.fieldNames == fieldNamesBoth && jsonName != protoName:
+		return []string{jsonName, protoName}
+	default:
+		return []string{jsonName}
+	}
+}
+
+// schemaForPresence matches objects that set the field using exactly one of its property names.
+func (m *Module) schemaForPresence(field pgs.Field) jsonschema.NonTrivialSchema {
+	m.Debug("schemaForPresence")
+	names := m.propertyNames(field)
+	schemas := make([]jsonschema.NonTrivialSchema, len(names))
+	for i, name := range names {
+		schema := jsonschema.NewObjectSchema()
+		schema.Required = []string{name}
+		schemas[i] = schema
+	}
+
+	return jsonschema.OneOf(schemas...)
 }
 
 func (m *Module) schemaForField(field pgs.Field) (jsonschema.Schema, bool) {
-	m.Push(fmt.Sprintf("field:%s", field.Name()))
+	m.Push(fmt.Sprintf("field:%s", fieldName(field)))
 	defer m.Pop()
 	m.Debug("schemaForField")
 
-	rules := &validate.FieldRules{}
-	_, err := field.Extension(validate.E_Field, rules)
-	m.CheckErr(err, "unable to read validation rules from field")
+	rules := m.fieldRules(field)
+	options := m.fieldOptions(field)
 
 	required := rules.GetRequired()
 	if rules.GetIgnore() == validate.Ignore_IGNORE_IF_ZERO_VALUE {
 		required = false
 	}
 
-	if field.HasOptionalKeyword() {
+	if m.hasOptionalKeyword(field) {
 		required = false
 	}
 
+	// protojson rejects messages that leave legacy required fields unset, whatever the validation rules say.
+	if m.fieldPresence(field) == descriptorpb.FeatureSet_LEGACY_REQUIRED {
+		required = true
+	}
+
 	var schema jsonschema.Schema
 	switch {
 	case field.Type().IsEmbed():
 		schema = m.schemaForEmbed(field.Type().Embed(), rules)
 	case field.Type().IsEnum():
-		schema = m.schemaForEnum(field.Type().Enum(), rules.GetEnum())
+		schema = m.schemaForEnum(field.Type().Enum(), rules.GetEnum(), required && !m.hasPresence(field))
 	case field.Type().IsMap():
 		schema = m.schemaForMap(field.Type().Element(), rules.GetMap())
 	case field.Type().IsRepeated():
@@ -77,13 +179,60 @@ func (m *Module) schemaForField(field pgs.Field) (jsonschema.Schema, bool) {
 		schema = m.schemaForScalar(field.Type().ProtoType(), rules)
 	}
 
+	if override := m.overrideSchema(options); override != nil {
+		schema = override
+	} else if documented, ok := schema.(jsonschema.NonTrivialSchema); ok {
+		cel := m.celRules(rules)
+		constraints, untranslated := m.translateCEL(cel, m.fieldCELTranslator(field))
+		documented = jsonschema.AllOf(append([]jsonschema.NonTrivialSchema{documented}, constraints...)...)
+		documented.Document("", describeCEL(m.comments(field), cel))
+
+		defaultValue := options.Default
+		if defaultValue == nil {
+			defaultValue = m.fieldDefault(field)
+		}
+
+		annotations := m.annotations(options.GetExamples(), defaultValue, options.GetDeprecated() || field.Descriptor().GetOptions().GetDeprecated())
+		annotations.CEL = untranslated
+		annotations.ReadOnly = options.GetReadOnly()
+		annotations.WriteOnly = options.GetWriteOnly()
+
+		var keywords map[string]any
+		if options.Format != nil {
+			keywords = map[string]any{"format": options.GetFormat()}
+		}
+
+		schema = m.customiseSchema(documented, options, annotations, keywords)
+	}
+
 	return schema, required && !field.InOneOf()
 }
 
+func (m *Module) fieldRules(field pgs.Field) *validate.FieldRules {
+	m.Debug("fieldRules")
+	rules := &validate.FieldRules{}
+	_, err := field.Extension(validate.E_Field, rules)
+	m.checkErr(err, "unable to read validation rules from field")
+
+	if t, ok := m.googleType(fieldParent(field)); ok {
+		proto.Merge(rules, t.rules[field.Name().String()])
+	}
+
+	return rules
+}
+
+func (m *Module) messageRules(message pgs.Message) *validate.MessageRules {
+	m.Debug("messageRules")
+	rules := &validate.MessageRules{}
+	_, err := message.Extension(validate.E_Message, rules)
+	m.checkErr(err, "unable to read validation rules from message")
+	return rules
+}
+
 func (m *Module) schemaForEmbed(embed pgs.Message, rules *validate.FieldRules) jsonschema.Schema {
 	m.Debug("schemaForEmbed")
-	if embed.IsWellKnown() {
-		return m.schemaForWellKnownType(embed.WellKnownType(), rules)
+	if name, ok := wellKnownTypeOf(embed); ok {
+		return m.schemaForWellKnownType(name, rules)
 	}
 
 	return m.schemaForMessage(embed)
@@ -98,24 +247,36 @@ func (m *Module) schemaForOneOf(oneOf pgs.OneOf) jsonschema.NonTrivialSchema {
 	m.Debug("schemaForOneOf")
 	rules := validate.OneofRules{}
 	_, err := oneOf.Extension(validate.E_Oneof, &rules)
-	m.CheckErr(err, "unable to read oneOf option")
+	m.checkErr(err, "unable to read oneOf option")
 
-	if rules.Required == nil || !*rules.Required {
-		return nil
+	schemas := make([]jsonschema.NonTrivialSchema, 0, len(oneOf.Fields()))
+	for _, field := range oneOf.Fields() {
+		if m.fieldOptions(field).GetHidden() {
+			continue
+		}
+
+		schemas = append(schemas, m.schemaForPresence(field))
 	}
 
-	schemas := make([]jsonschema.NonTrivialSchema, len(oneOf.Fields()))
-	for i, field := range oneOf.Fields() {
-		schema := jsonschema.NewObjectSchema()
-		schema.Required = []string{m.propertyName(field)}
-		schemas[i] = schema
+	if rules.GetRequired() {
+		return jsonschema.OneOf(schemas...)
 	}
 
-	return jsonschema.OneOf(schemas...)
+	if len(schemas) < 2 {
+		return nil
+	}
+
+	// protojson rejects documents that set more than one member, even when none are required.
+	none := jsonschema.Not(jsonschema.AnyOf(schemas...))
+	return jsonschema.OneOf(append(schemas, none)...)
 }
 
 func (m *Module) messageRef(message pgs.Message) jsonschema.Schema {
 	m.Debug("messageRef")
+	if ref, ok := m.externalRef(message); ok {
+		return jsonschema.Ref(ref)
+	}
+
 	return m.ref(message, func() jsonschema.Schema {
 		return m.defineMessage(message)
 	})
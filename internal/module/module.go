@@ -6,52 +6,237 @@ package module
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
 
 	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
 )
 
+const (
+	layoutMessage = "message"
+	layoutFile    = "file"
+	layoutPackage = "package"
+	layoutBundle  = "bundle"
+
+	enumsNames   = "names"
+	enumsNumbers = "numbers"
+	enumsBoth    = "both"
+
+	fieldNamesJSON  = "json"
+	fieldNamesProto = "proto"
+	fieldNamesBoth  = "both"
+
+	refsInline   = "inline"
+	refsExternal = "external"
+	refsRelative = "relative"
+
+	bundleFilename = "bundle.schema.json"
+)
+
+// Module builds the schemas. It is driven by the plugin, by Generate and by Schema, and reports problems as diagnostics
+// rather than failing, so that each of them can surface the problems in its own way.
 type Module struct {
-	*pgs.ModuleBase
+	debugger           debugger
 	nestedUnderMessage pgs.Message
 	definitions        map[string]jsonschema.Schema
+	diagnostics        []diagnostic
+	documents          map[string]string
+	files              []*pluginpb.CodeGeneratorResponse_File
+	baseURL            string
+	coverage           string
+	document           string
+	draft              jsonschema.Draft
+	enums              string
+	fieldNames         string
+	layout             string
+	refs               string
+	location           []string
+	googleTypes        bool
+	strict             bool
 }
 
-func New() pgs.Module {
-	return &Module{ModuleBase: &pgs.ModuleBase{}}
+// debugger receives the traces of the builder when the plugin runs with debugging enabled.
+type debugger interface {
+	Debug(v ...any)
 }
 
-func (*Module) Name() string {
-	return "jsonschema"
+// newModule reads the parameters, which are validated before anything is generated. The debugger may be nil.
+func newModule(parameters pgs.Parameters, debugger debugger) (*Module, error) {
+	m := &Module{debugger: debugger}
+	if err := m.configure(parameters); err != nil {
+		return nil, err
+	}
+
+	return m, nil
 }
 
-func (m *Module) Execute(targets map[string]pgs.File, _ map[string]pgs.Package) []pgs.Artifact {
-	baseURL := m.Parameters().StrDefault("baseurl", "https://protoc-gen-jsonschema.cerbos.dev/")
-	if !strings.HasSuffix(baseURL, "/") {
-		baseURL += "/"
+// generate converts the messages of the targets into schema documents, which it returns along with the coverage report
+// if one was asked for. Problems are recorded as diagnostics.
+func (m *Module) generate(targets map[string]pgs.File) []*pluginpb.CodeGeneratorResponse_File {
+	var files []pgs.File
+	for _, name := range slices.Sorted(maps.Keys(targets)) {
+		if file := targets[name]; !m.fileOptions(file).GetSkip() {
+			files = append(files, file)
+		}
 	}
 
-	for _, file := range targets {
-		m.Push(fmt.Sprintf("file:%s", file.Name()))
-
+	m.documents = make(map[string]string)
+	for _, file := range files {
 		for _, message := range file.AllMessages() {
-			filename := m.filename(message)
+			m.documents[message.FullyQualifiedName()] = m.documentFilename(message)
+		}
+	}
+
+	coverage := m.checkCoverage(files)
+	if m.coverage != "" {
+		m.reportCoverage(m.coverage, coverage)
+	}
+
+	switch m.layout {
+	case layoutMessage:
+		for _, file := range files {
+			m.generateMessageDocuments(file)
+		}
+
+	case layoutFile:
+		for _, file := range files {
+			options := m.fileOptions(file)
+			title := options.GetTitle()
+			if title == "" {
+				title = file.InputPath().String()
+			}
+			m.generateBundle(m.fileFilename(file), title, options.GetDescription(), []pgs.File{file})
+		}
+
+	case layoutPackage:
+		packages := make(map[string][]pgs.File)
+		for _, file := range files {
+			name := file.Package().ProtoName().String()
+			packages[name] = append(packages[name], file)
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(packages)) {
+			m.generateBundle(m.packageFilename(name), name, "", packages[name])
+		}
+
+	case layoutBundle:
+		if len(files) > 0 {
+			m.generateBundle(bundleFilename, "", "", files)
+		}
+	}
+
+	return m.files
+}
+
+// configure reads the parameters, returning an error for any value that is not understood.
+func (m *Module) configure(parameters pgs.Parameters) error {
+	m.baseURL = parameters.StrDefault("baseurl", "https://protoc-gen-jsonschema.cerbos.dev/")
+	if !strings.HasSuffix(m.baseURL, "/") {
+		m.baseURL += "/"
+	}
+
+	m.coverage = parameters.Str("coverage")
+
+	draft, err := jsonschema.ParseDraft(parameters.StrDefault("draft", string(jsonschema.Draft07)))
+	if err != nil {
+		return fmt.Errorf("invalid draft parameter: %w", err)
+	}
+	m.draft = draft
+
+	m.enums = parameters.StrDefault("enums", enumsNames)
+	if m.enums != enumsNames && m.enums != enumsNumbers && m.enums != enumsBoth {
+		return fmt.Errorf("invalid enums parameter %q", m.enums)
+	}
 
-			schema := m.defineMessage(message)
-			schema.TopLevel(baseURL + filename)
+	m.fieldNames = parameters.StrDefault("field_names", fieldNamesJSON)
+	if m.fieldNames != fieldNamesJSON && m.fieldNames != fieldNamesProto && m.fieldNames != fieldNamesBoth {
+		return fmt.Errorf("invalid field_names parameter %q", m.fieldNames)
+	}
 
-			content, err := json.MarshalIndent(schema, "", "  ")
-			m.CheckErr(err, "failed to marshal JSON schema")
+	m.googleTypes, err = parameters.BoolDefault("google_types", true)
+	if err != nil {
+		return fmt.Errorf("invalid google_types parameter: %w", err)
+	}
 
-			m.AddGeneratorFile(filename, string(content)+"\n")
+	m.layout = parameters.StrDefault("layout", layoutMessage)
+	if m.layout != layoutMessage && m.layout != layoutFile && m.layout != layoutPackage && m.layout != layoutBundle {
+		return fmt.Errorf("invalid layout parameter %q", m.layout)
+	}
+
+	m.strict, err = parameters.BoolDefault("strict", false)
+	if err != nil {
+		return fmt.Errorf("invalid strict parameter: %w", err)
+	}
+
+	m.refs = parameters.StrDefault("refs", refsInline)
+	if m.refs != refsInline && m.refs != refsExternal && m.refs != refsRelative {
+		return fmt.Errorf("invalid refs parameter %q", m.refs)
+	}
+
+	return nil
+}
+
+func (m *Module) generateMessageDocuments(file pgs.File) {
+	m.Push(fmt.Sprintf("file:%s", file.Name()))
+	defer m.Pop()
+
+	for _, message := range file.AllMessages() {
+		m.document = m.filename(message)
+		m.generateDocument(m.document, m.defineMessage(message))
+	}
+}
+
+// generateBundle defines every message of the files side by side, so that references between them share one definition.
+func (m *Module) generateBundle(filename, title, description string, files []pgs.File) {
+	m.Push(fmt.Sprintf("bundle:%s", filename))
+	defer m.Pop()
+
+	m.document = filename
+	m.definitions = make(map[string]jsonschema.Schema)
+	for _, file := range files {
+		for _, message := range file.AllMessages() {
+			m.messageRef(message)
 		}
+	}
 
-		m.Pop()
+	schema := &jsonschema.GenericSchema{}
+	schema.Document(title, description)
+	schema.Define(m.draft, m.definitions)
+	m.definitions = nil
+
+	m.generateDocument(filename, schema)
+}
+
+func (m *Module) generateDocument(filename string, schema jsonschema.NonTrivialSchema) {
+	content, err := m.marshalDocument(filename, schema)
+	if m.checkErr(err, "failed to marshal JSON schema") {
+		return
 	}
 
-	return m.Artifacts()
+	m.addFile(filename, content)
+}
+
+func (m *Module) marshalDocument(filename string, schema jsonschema.NonTrivialSchema) (string, error) {
+	schema.TopLevel(m.draft, m.baseURL+filename)
+
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(content) + "\n", nil
+}
+
+func (m *Module) addFile(filename, content string) {
+	m.files = append(m.files, &pluginpb.CodeGeneratorResponse_File{
+		Name:    proto.String(filename),
+		Content: proto.String(content),
+	})
 }
 
 func (*Module) filename(message pgs.Message) string {
@@ -60,3 +245,25 @@ func (*Module) filename(message pgs.Message) string {
 	name = strings.ReplaceAll(name, ".", "/")
 	return name + ".schema.json"
 }
+
+// documentFilename is the name of the document that defines the message in the current layout.
+func (m *Module) documentFilename(message pgs.Message) string {
+	switch m.layout {
+	case layoutFile:
+		return m.fileFilename(message.File())
+	case layoutPackage:
+		return m.packageFilename(message.Package().ProtoName().String())
+	case layoutBundle:
+		return bundleFilename
+	default:
+		return m.filename(message)
+	}
+}
+
+func (*Module) fileFilename(file pgs.File) string {
+	return strings.TrimSuffix(file.InputPath().String(), ".proto") + ".schema.json"
+}
+
+func (*Module) packageFilename(name string) string {
+	return strings.ReplaceAll(name, ".", "/") + ".schema.json"
+}
@@ -5,12 +5,24 @@ package module_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	pgs "github.com/lyft/protoc-gen-star/v2"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
 
+	jsonschemapb "github.com/cerbos/protoc-gen-jsonschema/gen/pb/jsonschema"
 	"github.com/cerbos/protoc-gen-jsonschema/internal/common"
 	"github.com/cerbos/protoc-gen-jsonschema/internal/module"
 	"github.com/cerbos/protoc-gen-jsonschema/internal/test"
@@ -18,13 +30,351 @@ import (
 
 const requestName = "code_generator_request.pb.bin"
 
+var updateGolden = flag.Bool("update", false, "Update golden files")
+
 func TestModule(t *testing.T) {
-	reqFile, err := os.Open(test.PathToDir(t, requestName))
+	testCases := []struct {
+		name       string
+		parameters string
+		err        string
+		files      []string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:       "draft_2020_12",
+			parameters: "draft=2020-12",
+			files: []string{
+				"testproto/EmptyEmbeddedTest.schema.json",
+				"testproto/MapRulesTest.schema.json",
+				"testproto/StringWellKnownRulesTest.schema.json",
+			},
+		},
+		{
+			name:       "layout_file",
+			parameters: "layout=file",
+		},
+		{
+			name:       "layout_package",
+			parameters: "layout=package,draft=2020-12",
+		},
+		{
+			name:       "enums_numbers",
+			parameters: "enums=numbers",
+			files:      []string{"testproto/EditionsTest.schema.json", "testproto/EnumRulesTest.schema.json"},
+		},
+		{
+			name:       "enums_both",
+			parameters: "enums=both",
+			files:      []string{"testproto/EnumRulesTest.schema.json"},
+		},
+		{
+			name:       "field_names_proto",
+			parameters: "field_names=proto",
+			files: []string{
+				"testproto/OneOfRulesTest.schema.json",
+				"testproto/StringRulesTest.schema.json",
+			},
+		},
+		{
+			name:       "field_names_both",
+			parameters: "field_names=both",
+			files: []string{
+				"testproto/FieldConstraintTest.schema.json",
+				"testproto/OneOfRulesTest.schema.json",
+				"testproto/Proto2Test.schema.json",
+				"testproto/StringRulesTest.schema.json",
+			},
+		},
+		{
+			name:       "google_types_off",
+			parameters: "google_types=false",
+			files:      []string{"testproto/GoogleTypesTest.schema.json"},
+		},
+		{
+			name:       "refs_external",
+			parameters: "refs=external",
+			files: []string{
+				"testproto/EmptyEmbeddedTest.schema.json",
+				"testproto/OneOfRulesTest.schema.json",
+				"testproto/OptionsTest.schema.json",
+			},
+		},
+		{
+			name:       "refs_relative",
+			parameters: "refs=relative",
+			files: []string{
+				"testproto/EmptyEmbeddedTest.schema.json",
+				"testproto/EmptyEmbeddedTest/EmbeddedExpression.schema.json",
+			},
+		},
+		{
+			name:       "coverage",
+			parameters: "coverage=coverage.json",
+			files:      []string{"coverage.json"},
+		},
+		{
+			name:       "strict",
+			parameters: "strict=true",
+			err:        "warning: file:testproto/testproto.proto message:OptionsTest: field hidden_required_field is hidden but required, so no document can be valid",
+		},
+		{
+			name:       "layout_bundle",
+			parameters: "layout=bundle",
+		},
+		{
+			name:       "invalid_parameter",
+			parameters: "layout=none",
+			err:        `invalid layout parameter "none"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := render(t, tc.parameters)
+			if tc.err != "" {
+				require.Contains(t, res.GetError(), tc.err)
+				return
+			}
+			require.Empty(t, res.GetError())
+
+			goldenDir := test.PathToDir(t, filepath.Join("golden", tc.name))
+			if *updateGolden {
+				require.NoError(t, os.RemoveAll(goldenDir))
+			}
+
+			generated := make(map[string]string, len(res.File))
+			for _, file := range res.File {
+				generated[file.GetName()] = file.GetContent()
+			}
+
+			files := tc.files
+			if len(files) == 0 {
+				for name := range generated {
+					files = append(files, name)
+				}
+			}
+
+			for _, name := range files {
+				content, ok := generated[name]
+				require.True(t, ok, "expected %s to be generated", name)
+
+				path := filepath.Join(goldenDir, name)
+				if *updateGolden {
+					require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+					require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+					continue
+				}
+
+				want, err := os.ReadFile(path)
+				require.NoError(t, err)
+				require.Equal(t, string(want), content, name)
+			}
+		})
+	}
+}
+
+// TestDiagnostics checks that every problem is reported with its location, and that the other files are still generated.
+func TestDiagnostics(t *testing.T) {
+	req := loadRequest(t)
+	req.FileToGenerate = append(req.FileToGenerate, "testproto/diagnostics.proto")
+	req.ProtoFile = append(req.ProtoFile, diagnosticsFile())
+
+	files, err := module.Generate(req, io.Discard)
+	require.Error(t, err)
+
+	diagnostics := strings.Split(err.Error(), "; ")
+	require.Equal(t, []string{
+		`error: file:testproto/diagnostics.proto message:DiagnosticsTest field:override_field: invalid override option: failed to unmarshal schema: unexpected end of JSON input`,
+		`error: file:testproto/diagnostics.proto message:DiagnosticsTest field:example_field: invalid examples option: "{" is not valid JSON`,
+		`error: file:testproto/diagnostics.proto message:DiagnosticsTest field:pattern_field: failed to parse regular expression "(": error parsing regexp: missing closing ): ` + "`(`",
+	}, diagnostics)
+
+	generated := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		generated[file.GetName()] = struct{}{}
+	}
+	require.Contains(t, generated, "testproto/DiagnosticsTest.schema.json")
+	require.Contains(t, generated, "testproto/StringRulesTest.schema.json")
+	require.Contains(t, generated, "testproto/Proto2Test.schema.json")
+}
+
+// diagnosticsFile has a message with three fields whose options are invalid.
+func diagnosticsFile() *descriptorpb.FileDescriptorProto {
+	stringField := func(name string, number int32, options *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Options:  options,
+		}
+	}
+
+	overrideOptions := &descriptorpb.FieldOptions{}
+	proto.SetExtension(overrideOptions, jsonschemapb.E_Field, &jsonschemapb.FieldOptions{Override: proto.String("{")})
+
+	exampleOptions := &descriptorpb.FieldOptions{}
+	proto.SetExtension(exampleOptions, jsonschemapb.E_Field, &jsonschemapb.FieldOptions{Examples: []string{"{"}})
+
+	patternOptions := &descriptorpb.FieldOptions{}
+	proto.SetExtension(patternOptions, validate.E_Field, &validate.FieldRules{
+		Type: &validate.FieldRules_String_{String_: &validate.StringRules{Pattern: proto.String("(")}},
+	})
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("testproto/diagnostics.proto"),
+		Package:    proto.String("testproto"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"buf/validate/validate.proto", "jsonschema/options.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("DiagnosticsTest"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				stringField("override_field", 1, overrideOptions),
+				stringField("example_field", 2, exampleOptions),
+				stringField("pattern_field", 3, patternOptions),
+			},
+		}},
+	}
+}
+
+// TestPatterns checks that every pattern in the golden files compiles with Go's regexp package, which is stricter than
+// ECMAScript about some constructs (such as repeat counts over 1000) and is used by Go validators.
+func TestPatterns(t *testing.T) {
+	goldenDir := os.DirFS(test.PathToDir(t, "golden"))
+	err := fs.WalkDir(goldenDir, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(goldenDir, path)
+		if err != nil {
+			return err
+		}
+
+		var schema any
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return err
+		}
+
+		for _, pattern := range collectPatterns(schema) {
+			_, err := regexp.Compile(pattern)
+			require.NoError(t, err, "%s: %s", path, pattern)
+		}
+
+		return nil
+	})
 	require.NoError(t, err)
+}
+
+// TestRefSiblings checks that draft 07 documents put no keywords beside $ref, because draft 07 ignores them.
+func TestRefSiblings(t *testing.T) {
+	goldenDir := os.DirFS(test.PathToDir(t, "golden"))
+	err := fs.WalkDir(goldenDir, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		data, err := fs.ReadFile(goldenDir, path)
+		if err != nil {
+			return err
+		}
+
+		var schema map[string]any
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return err
+		}
+
+		if schema["$schema"] == "http://json-schema.org/draft-07/schema#" {
+			for _, siblings := range collectRefSiblings(schema) {
+				require.Empty(t, siblings, "%s: keywords beside $ref", path)
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func collectRefSiblings(schema any) [][]string {
+	var siblings [][]string
+	switch value := schema.(type) {
+	case map[string]any:
+		if _, ok := value["$ref"]; ok {
+			keywords := make([]string, 0, len(value)-1)
+			for key := range value {
+				if key != "$ref" {
+					keywords = append(keywords, key)
+				}
+			}
+			siblings = append(siblings, keywords)
+		}
+
+		for _, child := range value {
+			siblings = append(siblings, collectRefSiblings(child)...)
+		}
+
+	case []any:
+		for _, child := range value {
+			siblings = append(siblings, collectRefSiblings(child)...)
+		}
+	}
+
+	return siblings
+}
+
+func collectPatterns(schema any) []string {
+	var patterns []string
+	switch value := schema.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if pattern, ok := child.(string); ok && key == "pattern" {
+				patterns = append(patterns, pattern)
+			} else {
+				patterns = append(patterns, collectPatterns(child)...)
+			}
+		}
+
+	case []any:
+		for _, child := range value {
+			patterns = append(patterns, collectPatterns(child)...)
+		}
+	}
+
+	return patterns
+}
+
+func render(t *testing.T, parameters string) *pluginpb.CodeGeneratorResponse {
+	t.Helper()
+
+	req := loadRequest(t)
+	req.Parameter = proto.String(parameters)
+	module.PrepareRequest(req)
+
+	reqBytes, err := proto.Marshal(req)
+	require.NoError(t, err)
+
 	resBytes := &bytes.Buffer{}
 	pgs.Init(
 		pgs.DebugEnv(common.DebugEnv),
-		pgs.ProtocInput(reqFile),
+		pgs.ProtocInput(bytes.NewReader(reqBytes)),
 		pgs.ProtocOutput(resBytes),
 	).RegisterModule(module.New()).Render()
+
+	res := &pluginpb.CodeGeneratorResponse{}
+	require.NoError(t, proto.Unmarshal(resBytes.Bytes(), res))
+	return res
+}
+
+func loadRequest(t *testing.T) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+
+	reqBytes, err := os.ReadFile(test.PathToDir(t, requestName))
+	require.NoError(t, err)
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	require.NoError(t, proto.Unmarshal(reqBytes, req))
+	return req
 }
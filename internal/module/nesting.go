@@ -5,6 +5,7 @@ package module
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	pgs "github.com/lyft/protoc-gen-star/v2"
@@ -20,7 +21,7 @@ func (m *Module) pushMessage(message pgs.Message) {
 	m.Push(fmt.Sprintf("message:%s", message.Name()))
 	m.Debug("pushMessage")
 
-	if m.nestedUnderMessage == nil {
+	if m.nestedUnderMessage == nil && m.definitions == nil {
 		m.nestedUnderMessage = message
 		m.definitions = make(map[string]jsonschema.Schema)
 	}
@@ -29,7 +30,7 @@ func (m *Module) pushMessage(message pgs.Message) {
 func (m *Module) popMessage(message pgs.Message, schema jsonschema.NonTrivialSchema) {
 	m.Debug("popMessage")
 	if m.nestedUnder(message) {
-		schema.Define(m.definitions)
+		schema.Define(m.draft, m.definitions)
 		m.definitions = nil
 		m.nestedUnderMessage = nil
 	}
@@ -50,9 +51,37 @@ func (m *Module) ref(entity namedEntity, schema func() jsonschema.Schema) *jsons
 		m.definitions[key] = schema()
 	}
 
-	return jsonschema.Ref("#/definitions/" + key)
+	return jsonschema.Ref(m.draft.DefinitionRef(key))
+}
+
+// externalRef points at the definition of a message in another generated document, if refs are not inlined.
+// Messages without a document of their own, such as those in skipped or imported files, are always inlined.
+func (m *Module) externalRef(message pgs.Message) (string, bool) {
+	m.Debug("externalRef")
+	if m.refs == refsInline {
+		return "", false
+	}
+
+	document, ok := m.documents[message.FullyQualifiedName()]
+	if !ok || document == m.document {
+		return "", false
+	}
+
+	ref := m.baseURL + document
+	if m.refs == refsRelative {
+		relative, err := filepath.Rel(filepath.Dir(m.document), document)
+		if !m.checkErr(err, "unable to make reference relative") {
+			ref = filepath.ToSlash(relative)
+		}
+	}
+
+	if m.layout != layoutMessage {
+		ref += m.draft.DefinitionRef(strings.TrimPrefix(message.FullyQualifiedName(), "."))
+	}
+
+	return ref, true
 }
 
 func (m *Module) nestedUnder(entity namedEntity) bool {
-	return entity.FullyQualifiedName() == m.nestedUnderMessage.FullyQualifiedName()
+	return m.nestedUnderMessage != nil && entity.FullyQualifiedName() == m.nestedUnderMessage.FullyQualifiedName()
 }
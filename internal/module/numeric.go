@@ -91,8 +91,8 @@ func (m *Module) valueSchemaForNumericScalar(numeric pgs.ProtoType) *jsonschema.
 		return jsonschema.NewNumberSchema()
 
 	default:
-		m.Failf("unknown numeric scalar type %q", numeric)
-		return nil
+		m.errorf("unknown numeric scalar type %q", numeric)
+		return jsonschema.NewNumberSchema()
 	}
 }
 
@@ -162,7 +162,7 @@ func (m *Module) numericRules(numeric pgs.ProtoType, rules *validate.FieldRules)
 		source = rules.GetUint64()
 
 	default:
-		m.Failf("unknown numeric scalar type %q", numeric)
+		m.errorf("unknown numeric scalar type %q", numeric)
 		return nil
 	}
 
@@ -171,11 +171,15 @@ func (m *Module) numericRules(numeric pgs.ProtoType, rules *validate.FieldRules)
 	}
 
 	data, err := json.Marshal(source)
-	m.CheckErr(err, "failed to marshal numeric validation rules to JSON")
+	if m.checkErr(err, "failed to marshal numeric validation rules to JSON") {
+		return nil
+	}
 
 	target := &numericRules{}
 	err = json.Unmarshal(data, target)
-	m.CheckErr(err, "failed to unmarshal numeric validation rules from JSON")
+	if m.checkErr(err, "failed to unmarshal numeric validation rules from JSON") {
+		return nil
+	}
 
 	return target
 }
@@ -0,0 +1,139 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"encoding/json"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+
+	jsonschemapb "github.com/cerbos/protoc-gen-jsonschema/gen/pb/jsonschema"
+	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
+)
+
+type schemaOptions interface {
+	GetTitle() string
+	GetDescription() string
+	GetExtra() string
+	GetOverride() string
+}
+
+func (m *Module) fileOptions(file pgs.File) *jsonschemapb.FileOptions {
+	m.Debug("fileOptions")
+	options := &jsonschemapb.FileOptions{}
+	_, err := file.Extension(jsonschemapb.E_File, options)
+	m.checkErr(err, "unable to read jsonschema options from file")
+	return options
+}
+
+func (m *Module) messageOptions(message pgs.Message) *jsonschemapb.MessageOptions {
+	m.Debug("messageOptions")
+	options := &jsonschemapb.MessageOptions{}
+	_, err := message.Extension(jsonschemapb.E_Message, options)
+	m.checkErr(err, "unable to read jsonschema options from message")
+	return options
+}
+
+func (m *Module) fieldOptions(field pgs.Field) *jsonschemapb.FieldOptions {
+	m.Debug("fieldOptions")
+	options := &jsonschemapb.FieldOptions{}
+	_, err := field.Extension(jsonschemapb.E_Field, options)
+	m.checkErr(err, "unable to read jsonschema options from field")
+	return options
+}
+
+func (m *Module) enumOptions(enum pgs.Enum) *jsonschemapb.EnumOptions {
+	m.Debug("enumOptions")
+	options := &jsonschemapb.EnumOptions{}
+	_, err := enum.Extension(jsonschemapb.E_Enum, options)
+	m.checkErr(err, "unable to read jsonschema options from enum")
+	return options
+}
+
+func (m *Module) overrideSchema(options schemaOptions) jsonschema.NonTrivialSchema {
+	m.Debug("overrideSchema")
+	if options.GetOverride() == "" {
+		return nil
+	}
+
+	schema, err := jsonschema.NewRawSchema([]byte(options.GetOverride()))
+	if err != nil {
+		m.errorf("invalid override option: %v", err)
+		return nil
+	}
+
+	return schema
+}
+
+// customiseSchema applies the options to a generated schema. Keywords from the extra option are merged last, so they win.
+func (m *Module) customiseSchema(schema jsonschema.NonTrivialSchema, options schemaOptions, annotations jsonschema.Annotations, keywords map[string]any) jsonschema.NonTrivialSchema {
+	m.Debug("customiseSchema")
+	description := options.GetDescription()
+	if description != "" {
+		description = describeCEL(description, annotations.CEL)
+	}
+
+	schema.Document(options.GetTitle(), description)
+	schema.Annotate(annotations)
+
+	if len(keywords) > 0 {
+		data, err := json.Marshal(keywords)
+		if !m.checkErr(err, "failed to marshal schema keywords") {
+			schema = m.mergeSchema(schema, data)
+		}
+	}
+
+	if options.GetExtra() != "" {
+		schema = m.mergeSchema(schema, []byte(options.GetExtra()))
+	}
+
+	m.isolateRef(schema)
+	return schema
+}
+
+// isolateRef keeps the keywords beside a $ref in effect for draft 07, which ignores them otherwise.
+func (m *Module) isolateRef(schema jsonschema.NonTrivialSchema) {
+	if m.draft == jsonschema.Draft07 {
+		schema.IsolateRef()
+	}
+}
+
+func (m *Module) mergeSchema(schema jsonschema.NonTrivialSchema, data []byte) jsonschema.NonTrivialSchema {
+	m.Debug("mergeSchema")
+	merged, err := jsonschema.Merge(schema, data)
+	if err != nil {
+		m.errorf("invalid extra option: %v", err)
+		return schema
+	}
+
+	return merged
+}
+
+func (m *Module) annotations(examples []string, defaultValue *string, deprecated bool) jsonschema.Annotations {
+	m.Debug("annotations")
+	annotations := jsonschema.Annotations{Deprecated: deprecated}
+
+	if defaultValue != nil {
+		if m.validJSON(*defaultValue, "default") {
+			annotations.Default = json.RawMessage(*defaultValue)
+		}
+	}
+
+	for _, example := range examples {
+		if m.validJSON(example, "examples") {
+			annotations.Examples = append(annotations.Examples, json.RawMessage(example))
+		}
+	}
+
+	return annotations
+}
+
+func (m *Module) validJSON(value, option string) bool {
+	if !json.Valid([]byte(value)) {
+		m.errorf("invalid %s option: %q is not valid JSON", option, value)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,39 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	pgs "github.com/lyft/protoc-gen-star/v2"
+)
+
+// plugin runs the schema builder as a pgs module, turning its files and diagnostics into artifacts.
+type plugin struct {
+	*pgs.ModuleBase
+}
+
+func New() pgs.Module {
+	return &plugin{ModuleBase: &pgs.ModuleBase{}}
+}
+
+func (*plugin) Name() string {
+	return "jsonschema"
+}
+
+func (p *plugin) Execute(targets map[string]pgs.File, _ map[string]pgs.Package) []pgs.Artifact {
+	m, err := newModule(p.Parameters(), p)
+	if err != nil {
+		p.AddError(err.Error())
+		return p.Artifacts()
+	}
+
+	for _, file := range m.generate(targets) {
+		p.AddGeneratorFile(file.GetName(), file.GetContent())
+	}
+
+	for _, message := range m.reportDiagnostics(p.Log) {
+		p.AddError(message)
+	}
+
+	return p.Artifacts()
+}
@@ -0,0 +1,49 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// PrepareRequest rewrites the parts of a request that pgs refuses to process.
+// Group fields become delimited message fields, which is how editions describe them.
+func PrepareRequest(req *pluginpb.CodeGeneratorRequest) {
+	prepareFiles(req.GetProtoFile())
+}
+
+func prepareFiles(files []*descriptorpb.FileDescriptorProto) {
+	for _, file := range files {
+		prepareFields(file.GetExtension())
+		for _, message := range file.GetMessageType() {
+			prepareMessage(message)
+		}
+	}
+}
+
+func prepareMessage(message *descriptorpb.DescriptorProto) {
+	prepareFields(message.GetField())
+	prepareFields(message.GetExtension())
+	for _, nested := range message.GetNestedType() {
+		prepareMessage(nested)
+	}
+}
+
+func prepareFields(fields []*descriptorpb.FieldDescriptorProto) {
+	for _, field := range fields {
+		if field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+			continue
+		}
+
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		if field.Options == nil {
+			field.Options = &descriptorpb.FieldOptions{}
+		}
+		if field.Options.Features == nil {
+			field.Options.Features = &descriptorpb.FeatureSet{}
+		}
+		field.Options.Features.MessageEncoding = descriptorpb.FeatureSet_DELIMITED.Enum()
+	}
+}
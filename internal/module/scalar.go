@@ -4,11 +4,13 @@
 package module
 
 import (
+	"fmt"
 	"io"
 	"regexp"
 	"regexp/syntax"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	pgs "github.com/lyft/protoc-gen-star/v2"
@@ -16,6 +18,26 @@ import (
 	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
 )
 
+const (
+	hostnamePattern     = `[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*\.?`
+	ipv4Pattern         = `(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)`
+	ipv4PrefixLen       = `(?:3[0-2]|[12]?\d)`
+	ipv6PrefixLen       = `(?:12[0-8]|1[01]\d|[1-9]?\d)`
+	portPattern         = `(?:6553[0-5]|655[0-2]\d|65[0-4]\d{2}|6[0-4]\d{3}|[1-5]\d{4}|[1-9]\d{0,3}|0)`
+	protobufNamePattern = `[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*`
+
+	httpHeaderNamePattern       = "^:?[0-9A-Za-z!#$%&'*+\\-.^_|~`]+$"
+	httpHeaderValuePattern      = `^[^\x00-\x08\x0A-\x1F\x7F]*$`
+	looseHTTPHeaderNamePattern  = `^[^\x00\x0A\x0D]+$`
+	looseHTTPHeaderValuePattern = `^[^\x00\x0A\x0D]*$`
+
+	tuuidPattern = `^[0-9A-Fa-f]{32}$`
+	ulidPattern  = `^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`
+	uuidPattern  = `^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`
+)
+
+var ipv6Pattern = makeIPv6Pattern()
+
 func (m *Module) schemaForScalar(scalar pgs.ProtoType, rules *validate.FieldRules) jsonschema.Schema {
 	m.Debug("schemaForScalar")
 	if scalar.IsNumeric() {
@@ -26,12 +48,12 @@ func (m *Module) schemaForScalar(scalar pgs.ProtoType, rules *validate.FieldRule
 	case pgs.BoolT:
 		return m.schemaForBool(rules.GetBool())
 	case pgs.BytesT:
-		return m.schemaForBytes()
+		return m.schemaForBytes(rules.GetBytes())
 	case pgs.StringT:
 		return m.schemaForString(rules.GetString())
 	default:
-		m.Failf("unexpected scalar type %q", scalar)
-		return nil
+		m.errorf("unexpected scalar type %q", scalar)
+		return jsonschema.True
 	}
 }
 
@@ -48,22 +70,6 @@ func (m *Module) schemaForBool(rules *validate.BoolRules) jsonschema.Schema {
 	return schema
 }
 
-func (m *Module) schemaForBytes() jsonschema.Schema {
-	m.Debug("schemaForBytes")
-
-	standard := jsonschema.NewStringSchema()
-	standard.Title = "Standard base64 encoding"
-	standard.Pattern = `^[\r\nA-Za-z0-9+/]*$`
-
-	urlSafe := jsonschema.NewStringSchema()
-	urlSafe.Title = "URL-safe base64 encoding"
-	urlSafe.Pattern = `^[\r\nA-Za-z0-9_-]*$`
-
-	schema := jsonschema.NewStringSchema()
-	schema.OneOf = []jsonschema.NonTrivialSchema{standard, urlSafe}
-	return schema
-}
-
 func (m *Module) schemaForString(rules *validate.StringRules) jsonschema.Schema {
 	m.Debug("schemaForString")
 	schema := jsonschema.NewStringSchema()
@@ -110,7 +116,9 @@ func (m *Module) schemaForString(rules *validate.StringRules) jsonschema.Schema
 		}
 
 		if rules.Pattern != nil {
-			patterns = append(patterns, m.makeRegexpCompatibleWithECMAScript(rules.GetPattern()))
+			if pattern, ok := m.makeRegexpCompatibleWithECMAScript(rules.GetPattern()); ok {
+				patterns = append(patterns, pattern)
+			}
 		}
 
 		if rules.Prefix != nil {
@@ -146,6 +154,41 @@ func (m *Module) schemaForString(rules *validate.StringRules) jsonschema.Schema
 
 			case *validate.StringRules_UriRef:
 				schema.Format = jsonschema.StringFormatURIReference
+
+			case *validate.StringRules_Uuid:
+				schema.Format = jsonschema.StringFormatUUID
+				if m.draft == jsonschema.Draft07 {
+					patterns = append(patterns, uuidPattern)
+				}
+
+			case *validate.StringRules_Tuuid:
+				patterns = append(patterns, tuuidPattern)
+
+			case *validate.StringRules_Ulid:
+				patterns = append(patterns, ulidPattern)
+
+			case *validate.StringRules_IpWithPrefixlen, *validate.StringRules_IpPrefix:
+				patterns = append(patterns, fmt.Sprintf("^(?:%s/%s|%s/%s)$", ipv4Pattern, ipv4PrefixLen, ipv6Pattern, ipv6PrefixLen))
+
+			case *validate.StringRules_Ipv4WithPrefixlen, *validate.StringRules_Ipv4Prefix:
+				patterns = append(patterns, fmt.Sprintf("^%s/%s$", ipv4Pattern, ipv4PrefixLen))
+
+			case *validate.StringRules_Ipv6WithPrefixlen, *validate.StringRules_Ipv6Prefix:
+				patterns = append(patterns, fmt.Sprintf("^%s/%s$", ipv6Pattern, ipv6PrefixLen))
+
+			case *validate.StringRules_HostAndPort:
+				patterns = append(patterns, fmt.Sprintf(`^(?:%s|%s|\[%s\]):%s$`, hostnamePattern, ipv4Pattern, ipv6Pattern, portPattern))
+
+			case *validate.StringRules_ProtobufFqn:
+				patterns = append(patterns, "^"+protobufNamePattern+"$")
+
+			case *validate.StringRules_ProtobufDotFqn:
+				patterns = append(patterns, `^\.`+protobufNamePattern+"$")
+
+			case *validate.StringRules_WellKnownRegex:
+				if pattern := m.patternForKnownRegex(rules.GetWellKnownRegex(), rules.Strict == nil || rules.GetStrict()); pattern != "" {
+					patterns = append(patterns, pattern)
+				}
 			}
 		}
 	}
@@ -163,6 +206,26 @@ func (m *Module) schemaForString(rules *validate.StringRules) jsonschema.Schema
 	return jsonschema.AllOf(schemas...)
 }
 
+func (m *Module) patternForKnownRegex(knownRegex validate.KnownRegex, strict bool) string {
+	m.Debug("patternForKnownRegex")
+	switch knownRegex {
+	case validate.KnownRegex_KNOWN_REGEX_HTTP_HEADER_NAME:
+		if strict {
+			return httpHeaderNamePattern
+		}
+		return looseHTTPHeaderNamePattern
+
+	case validate.KnownRegex_KNOWN_REGEX_HTTP_HEADER_VALUE:
+		if strict {
+			return httpHeaderValuePattern
+		}
+		return looseHTTPHeaderValuePattern
+
+	default:
+		return ""
+	}
+}
+
 func (m *Module) schemaForStringFormats(formats ...jsonschema.StringFormat) jsonschema.NonTrivialSchema {
 	m.Debug("schemaForStringFormats")
 	schemas := make([]jsonschema.NonTrivialSchema, len(formats))
@@ -176,14 +239,51 @@ func (m *Module) schemaForStringFormats(formats ...jsonschema.StringFormat) json
 	return jsonschema.AnyOf(schemas...)
 }
 
-func (m *Module) makeRegexpCompatibleWithECMAScript(pattern string) string {
+// makeIPv6Pattern follows the IPv6address rule from RFC 3986, section 3.2.2.
+func makeIPv6Pattern() string {
+	const (
+		h16  = `[0-9A-Fa-f]{1,4}`
+		ls32 = `(?:` + h16 + `:` + h16 + `|` + ipv4Pattern + `)`
+	)
+
+	tails := []string{
+		fmt.Sprintf("(?:%s:){4}%s", h16, ls32),
+		fmt.Sprintf("(?:%s:){3}%s", h16, ls32),
+		fmt.Sprintf("(?:%s:){2}%s", h16, ls32),
+		fmt.Sprintf("%s:%s", h16, ls32),
+		ls32,
+		h16,
+		"",
+	}
+
+	alternatives := make([]string, 0, len(tails)+2)
+	alternatives = append(alternatives,
+		fmt.Sprintf("(?:%s:){6}%s", h16, ls32),
+		fmt.Sprintf("::(?:%s:){5}%s", h16, ls32),
+	)
+
+	for i, tail := range tails {
+		head := fmt.Sprintf("(?:%s)?", h16)
+		if i > 0 {
+			head = fmt.Sprintf("(?:(?:%s:){0,%d}%s)?", h16, i, h16)
+		}
+		alternatives = append(alternatives, head+"::"+tail)
+	}
+
+	return "(?:" + strings.Join(alternatives, "|") + ")"
+}
+
+func (m *Module) makeRegexpCompatibleWithECMAScript(pattern string) (string, bool) {
 	m.Debug("makeRegexpCompatibleWithECMAScript")
 	expression, err := syntax.Parse(pattern, syntax.Perl)
-	m.CheckErr(err, "failed to parse regular expression")
+	if err != nil {
+		m.errorf("failed to parse regular expression %q: %v", pattern, err)
+		return "", false
+	}
 
 	var builder strings.Builder
 	writeECMAScriptCompatibleRegexp(&builder, expression)
-	return builder.String()
+	return builder.String(), true
 }
 
 func writeECMAScriptCompatibleRegexp(w io.StringWriter, expression *syntax.Regexp) {
@@ -249,6 +349,25 @@ func writeECMAScriptCompatibleRegexp(w io.StringWriter, expression *syntax.Regex
 			}
 			writeECMAScriptCompatibleRegexp(w, subexpression)
 		}
+	case syntax.OpLiteral:
+		if expression.Flags&syntax.FoldCase == 0 {
+			w.WriteString(expression.String()) //nolint:errcheck
+			break
+		}
+
+		// ECMAScript has no inline flags, so case-insensitive literals become character classes.
+		for _, r := range expression.Rune {
+			folds := string(r)
+			for fold := unicode.SimpleFold(r); fold != r; fold = unicode.SimpleFold(fold) {
+				folds += string(fold)
+			}
+
+			if len(folds) == len(string(r)) {
+				w.WriteString(regexp.QuoteMeta(folds)) //nolint:errcheck
+			} else {
+				w.WriteString("[" + folds + "]") //nolint:errcheck
+			}
+		}
 	default:
 		w.WriteString(expression.String()) //nolint:errcheck
 	}
@@ -0,0 +1,64 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package module
+
+import (
+	"fmt"
+	"io"
+
+	pgs "github.com/lyft/protoc-gen-star/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fileParameters only apply when generating files, so Schema rejects them.
+var fileParameters = []string{"coverage", "layout"}
+
+// Schema converts one message into a document of its own, without generating the files that define it.
+// The files must include the message's file and its transitive imports, in dependency order. References are always
+// inlined, and the skip file option is ignored because the message was asked for explicitly. Warnings are written to logs.
+func Schema(files []*descriptorpb.FileDescriptorProto, message string, parameters map[string]string, logs io.Writer) ([]byte, error) {
+	for _, key := range fileParameters {
+		if _, ok := parameters[key]; ok {
+			return nil, fmt.Errorf("the %s parameter does not apply to a single schema", key)
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{File: files}
+	set = proto.CloneOf(set)
+	prepareFiles(set.GetFile())
+	ast, err := buildAST(logs, func(debugger pgs.Debugger) pgs.AST {
+		return pgs.ProcessFileDescriptorSet(debugger, set)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entity, ok := ast.Lookup("." + message)
+	target, isMessage := entity.(pgs.Message)
+	if !ok || !isMessage {
+		return nil, fmt.Errorf("message %s not found", message)
+	}
+
+	m, err := newModule(pgs.Parameters(parameters), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.refs != refsInline {
+		return nil, fmt.Errorf("the refs parameter must be %q for a single schema", refsInline)
+	}
+
+	m.document = m.filename(target)
+	content, err := m.marshalDocument(m.document, m.defineMessage(target))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON schema: %w", err)
+	}
+
+	if err := diagnosticsError(m.reportDiagnostics(logger(logs))); err != nil {
+		return nil, err
+	}
+
+	return []byte(content), nil
+}
@@ -6,22 +6,30 @@ package module
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	pgs "github.com/lyft/protoc-gen-star/v2"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	duration "google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/cerbos/protoc-gen-jsonschema/internal/jsonschema"
 )
 
 type wellKnownType pgs.WellKnownType
 
+// fieldMaskWKT is missing from pgs, which describes FieldMask as an ordinary message. The other well-known types that
+// pgs does not list, such as Type, Api and SourceContext, have no special JSON mapping, so that is correct for them.
+const fieldMaskWKT pgs.WellKnownType = "FieldMask"
+
 const (
 	wellKnownTypeAny       = wellKnownType(pgs.AnyWKT)
 	wellKnownTypeDuration  = wellKnownType(pgs.DurationWKT)
 	wellKnownTypeEmpty     = wellKnownType(pgs.EmptyWKT)
+	wellKnownTypeFieldMask = wellKnownType(fieldMaskWKT)
 	wellKnownTypeListValue = wellKnownType(pgs.ListValueWKT)
 	wellKnownTypeStruct    = wellKnownType(pgs.StructWKT)
 	wellKnownTypeTimestamp = wellKnownType(pgs.TimestampWKT)
@@ -65,6 +73,15 @@ func (m *Module) defineEmpty() jsonschema.Schema {
 	return schema
 }
 
+func (m *Module) defineFieldMask() jsonschema.Schema {
+	m.Debug("defineFieldMask")
+	schema := jsonschema.NewStringSchema()
+	schema.Title = "FieldMask"
+	schema.Description = "A set of symbolic field paths, written as a comma-separated list of paths with camelCase field names."
+	schema.Pattern = fieldMaskPattern(fieldMaskPathPattern)
+	return schema
+}
+
 func (m *Module) defineListValue() jsonschema.Schema {
 	m.Debug("defineListValue")
 	schema := jsonschema.NewArraySchema()
@@ -96,8 +113,29 @@ func (m *Module) defineValue() jsonschema.Schema {
 	m.Debug("defineValue")
 	return &jsonschema.GenericSchema{
 		Title:       "Value",
-		Description: "A dynamically-typed value.",
+		Description: "A dynamically-typed value, which is either null, a boolean, a number, a string, a Struct or a ListValue.",
+		AnyOf: []jsonschema.NonTrivialSchema{
+			jsonschema.NewNullSchema(),
+			jsonschema.NewBooleanSchema(),
+			jsonschema.NewNumberSchema(),
+			jsonschema.NewStringSchema(),
+			m.ref(wellKnownTypeStruct, m.defineStruct),
+			m.ref(wellKnownTypeListValue, m.defineListValue),
+		},
+	}
+}
+
+// wellKnownTypeOf identifies well-known types, including those that pgs does not know about.
+func wellKnownTypeOf(message pgs.Message) (pgs.WellKnownType, bool) {
+	if message.IsWellKnown() {
+		return message.WellKnownType(), true
 	}
+
+	if message.FullyQualifiedName() == wellKnownTypeFieldMask.FullyQualifiedName() {
+		return fieldMaskWKT, true
+	}
+
+	return pgs.UnknownWKT, false
 }
 
 func (m *Module) schemaForWellKnownType(name pgs.WellKnownType, rules *validate.FieldRules) jsonschema.Schema {
@@ -108,13 +146,15 @@ func (m *Module) schemaForWellKnownType(name pgs.WellKnownType, rules *validate.
 	case pgs.BoolValueWKT:
 		return m.schemaForBool(rules.GetBool())
 	case pgs.BytesValueWKT:
-		return m.schemaForBytes()
+		return m.schemaForBytes(rules.GetBytes())
 	case pgs.DoubleValueWKT:
 		return m.schemaForNumericScalar(pgs.DoubleT, rules)
 	case pgs.DurationWKT:
 		return m.schemaForDuration(rules.GetDuration())
 	case pgs.EmptyWKT:
 		return m.ref(wellKnownTypeEmpty, m.defineEmpty)
+	case fieldMaskWKT:
+		return m.schemaForFieldMask(rules.GetFieldMask())
 	case pgs.FloatValueWKT:
 		return m.schemaForNumericScalar(pgs.FloatT, rules)
 	case pgs.Int32ValueWKT:
@@ -136,8 +176,8 @@ func (m *Module) schemaForWellKnownType(name pgs.WellKnownType, rules *validate.
 	case pgs.ValueWKT:
 		return m.ref(wellKnownTypeValue, m.defineValue)
 	default:
-		m.Failf("unexpected well-known type %q", name)
-		return nil
+		m.errorf("unexpected well-known type %q", name)
+		return jsonschema.True
 	}
 }
 
@@ -182,7 +222,23 @@ func (m *Module) schemaForDuration(rules *validate.DurationRules) jsonschema.Sch
 		}
 
 		if len(rules.NotIn) > 0 {
-			schemas = append(schemas, jsonschema.Not(m.schemaForDurationIn(rules.In)))
+			schemas = append(schemas, jsonschema.Not(m.schemaForDurationIn(rules.NotIn)))
+		}
+
+		switch upper := rules.LessThan.(type) {
+		case *validate.DurationRules_Lt:
+			schemas = append(schemas, m.schemaForDurationRange(upper.Lt, lessThan))
+
+		case *validate.DurationRules_Lte:
+			schemas = append(schemas, m.schemaForDurationRange(upper.Lte, lessThanOrEqual))
+		}
+
+		switch lower := rules.GreaterThan.(type) {
+		case *validate.DurationRules_Gt:
+			schemas = append(schemas, m.schemaForDurationRange(lower.Gt, greaterThan))
+
+		case *validate.DurationRules_Gte:
+			schemas = append(schemas, m.schemaForDurationRange(lower.Gte, greaterThanOrEqual))
 		}
 	}
 
@@ -198,6 +254,59 @@ func (m *Module) schemaForDurationIn(durations []*duration.Duration) *jsonschema
 	return schema
 }
 
+func (m *Module) schemaForDurationRange(bound *duration.Duration, c comparison) *jsonschema.StringSchema {
+	m.Debug("schemaForDurationRange")
+	schema := jsonschema.NewStringSchema()
+	schema.Pattern = durationRangePattern(bound, c)
+	return schema
+}
+
+func (m *Module) schemaForFieldMask(rules *validate.FieldMaskRules) jsonschema.Schema {
+	m.Debug("schemaForFieldMask")
+	schemas := []jsonschema.NonTrivialSchema{m.ref(wellKnownTypeFieldMask, m.defineFieldMask)}
+
+	if rules != nil {
+		if rules.Const != nil {
+			schemas = append(schemas, m.schemaForProtoJSONStringConst(rules.Const))
+		}
+
+		if len(rules.In) > 0 {
+			// Every path must be one of the listed paths or a subpath of one.
+			schema := jsonschema.NewStringSchema()
+			schema.Pattern = fieldMaskPattern(m.fieldMaskPathsPattern(rules.In) + `(?:\.` + fieldMaskPathPattern + `)?`)
+			schemas = append(schemas, schema)
+		}
+
+		if len(rules.NotIn) > 0 {
+			// No path may be one of the listed paths or a subpath of one.
+			schema := jsonschema.NewStringSchema()
+			schema.Pattern = `(?:^\s*|,)` + m.fieldMaskPathsPattern(rules.NotIn) + `(?:[.,]|\s*$)`
+			schemas = append(schemas, jsonschema.Not(schema))
+		}
+	}
+
+	return jsonschema.AllOf(schemas...)
+}
+
+// fieldMaskPathsPattern matches any of the paths, written as protojson writes them.
+func (m *Module) fieldMaskPathsPattern(paths []string) string {
+	m.Debug("fieldMaskPathsPattern")
+	alternatives := make([]string, len(paths))
+	for i, path := range paths {
+		alternatives[i] = regexp.QuoteMeta(m.protoJSONString(&fieldmaskpb.FieldMask{Paths: []string{path}}))
+	}
+
+	return fmt.Sprintf("(?:%s)", strings.Join(alternatives, "|"))
+}
+
+// fieldMaskPathPattern matches a path of camelCase field names, which protojson converts back to snake_case.
+const fieldMaskPathPattern = `[A-Za-z][A-Za-z0-9]*(?:\.[A-Za-z][A-Za-z0-9]*)*`
+
+// fieldMaskPattern matches a comma-separated list of paths that match the given pattern.
+func fieldMaskPattern(path string) string {
+	return fmt.Sprintf(`^\s*(?:%[1]s(?:,%[1]s)*)?\s*$`, path)
+}
+
 func (m *Module) schemaForTimestamp(rules *validate.TimestampRules) jsonschema.Schema {
 	m.Debug("schemaForTimestamp")
 	schemas := []jsonschema.NonTrivialSchema{m.ref(wellKnownTypeTimestamp, m.defineTimestamp)}
@@ -206,11 +315,58 @@ func (m *Module) schemaForTimestamp(rules *validate.TimestampRules) jsonschema.S
 		if rules.Const != nil {
 			schemas = append(schemas, m.schemaForProtoJSONStringConst(rules.Const))
 		}
+
+		if rules.LessThan != nil || rules.GreaterThan != nil || rules.Within != nil {
+			schemas = append(schemas, m.schemaForTimestampRange(rules))
+		}
 	}
 
 	return jsonschema.AllOf(schemas...)
 }
 
+func (m *Module) schemaForTimestampRange(rules *validate.TimestampRules) *jsonschema.StringSchema {
+	m.Debug("schemaForTimestampRange")
+	schema := jsonschema.NewStringSchema()
+	var descriptions []string
+
+	switch lessThan := rules.LessThan.(type) {
+	case *validate.TimestampRules_Lt:
+		schema.FormatExclusiveMaximum = jsonschema.String(m.protoJSONString(lessThan.Lt))
+
+	case *validate.TimestampRules_Lte:
+		schema.FormatMaximum = jsonschema.String(m.protoJSONString(lessThan.Lte))
+
+	case *validate.TimestampRules_LtNow:
+		if lessThan.LtNow {
+			schema.LessThanNow = true
+			descriptions = append(descriptions, "Must be in the past.")
+		}
+	}
+
+	switch greaterThan := rules.GreaterThan.(type) {
+	case *validate.TimestampRules_Gt:
+		schema.FormatExclusiveMinimum = jsonschema.String(m.protoJSONString(greaterThan.Gt))
+
+	case *validate.TimestampRules_Gte:
+		schema.FormatMinimum = jsonschema.String(m.protoJSONString(greaterThan.Gte))
+
+	case *validate.TimestampRules_GtNow:
+		if greaterThan.GtNow {
+			schema.GreaterThanNow = true
+			descriptions = append(descriptions, "Must be in the future.")
+		}
+	}
+
+	if rules.Within != nil {
+		within := m.protoJSONString(rules.Within)
+		schema.Within = jsonschema.String(within)
+		descriptions = append(descriptions, fmt.Sprintf("Must be within %s of the current time.", within))
+	}
+
+	schema.Description = strings.Join(descriptions, " ")
+	return schema
+}
+
 func (m *Module) schemaForProtoJSONStringConst(value proto.Message) *jsonschema.StringSchema {
 	m.Debug("schemaForProtoJSONStringConst")
 	schema := jsonschema.NewStringSchema()
@@ -221,11 +377,11 @@ func (m *Module) schemaForProtoJSONStringConst(value proto.Message) *jsonschema.
 func (m *Module) protoJSONString(value proto.Message) string {
 	m.Debug("protoJSONString")
 	data, err := protojson.Marshal(value)
-	m.CheckErr(err, "failed to marshal value to proto JSON")
+	m.checkErr(err, "failed to marshal value to proto JSON")
 
 	var result string
 	err = json.Unmarshal(data, &result)
-	m.CheckErr(err, "failed to unmarshal value from proto JSON")
+	m.checkErr(err, "failed to unmarshal value from proto JSON")
 
 	return result
 }
@@ -0,0 +1,95 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonschema generates JSON schemas for protobuf messages at runtime.
+//
+// It calls the plugin's schema builder directly rather than running the plugin, so no protoc request is built and
+// nothing is written to stdout or stderr. Problems that would fail the plugin are returned as errors, and warnings are
+// discarded.
+package jsonschema
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/cerbos/protoc-gen-jsonschema/internal/module"
+)
+
+type config struct {
+	parameters map[string]string
+}
+
+// Option configures the generated schema. Each option corresponds to a plugin parameter.
+type Option func(*config)
+
+// WithBaseURL sets the base URL used to build the $id of the schema.
+func WithBaseURL(baseURL string) Option {
+	return WithParameter("baseurl", baseURL)
+}
+
+// WithDraft sets the JSON schema dialect to target: "07", "2019-09" or "2020-12".
+func WithDraft(draft string) Option {
+	return WithParameter("draft", draft)
+}
+
+// WithEnums sets how enum values are accepted: "names", "numbers" or "both".
+func WithEnums(enums string) Option {
+	return WithParameter("enums", enums)
+}
+
+// WithFieldNames sets the property names to accept for each field: "json", "proto" or "both".
+func WithFieldNames(fieldNames string) Option {
+	return WithParameter("field_names", fieldNames)
+}
+
+// WithParameter sets a plugin parameter by name.
+func WithParameter(key, value string) Option {
+	return func(c *config) {
+		c.parameters[key] = value
+	}
+}
+
+// Generate returns the JSON schema that the plugin generates for the message, with references to other messages inlined.
+// The layout and coverage parameters only apply to generating files, so they are rejected, as is any refs parameter
+// other than "inline". The skip file option is ignored.
+func Generate(desc protoreflect.MessageDescriptor, opts ...Option) ([]byte, error) {
+	c := &config{parameters: make(map[string]string)}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	schema, err := module.Schema(fileDescriptorProtos(desc.ParentFile()), string(desc.FullName()), c.parameters, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema for %s: %w", desc.FullName(), err)
+	}
+
+	return schema, nil
+}
+
+// fileDescriptorProtos lists the file and its transitive imports, with every file after the files it imports.
+func fileDescriptorProtos(file protoreflect.FileDescriptor) []*descriptorpb.FileDescriptorProto {
+	var files []*descriptorpb.FileDescriptorProto
+	seen := make(map[string]struct{})
+
+	var add func(protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if _, ok := seen[fd.Path()]; ok {
+			return
+		}
+		seen[fd.Path()] = struct{}{}
+
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			add(imports.Get(i).FileDescriptor)
+		}
+
+		files = append(files, protodesc.ToFileDescriptorProto(fd))
+	}
+
+	add(file)
+	return files
+}
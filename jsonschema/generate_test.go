@@ -0,0 +1,105 @@
+// Copyright 2021-2026 Zenauth Ltd.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	jsonschemapb "github.com/cerbos/protoc-gen-jsonschema/gen/pb/jsonschema"
+	"github.com/cerbos/protoc-gen-jsonschema/internal/test"
+	"github.com/cerbos/protoc-gen-jsonschema/jsonschema"
+)
+
+func TestGenerate(t *testing.T) {
+	desc := (&jsonschemapb.FieldOptions{}).ProtoReflect().Descriptor()
+
+	t.Run("default", func(t *testing.T) {
+		data, err := jsonschema.Generate(desc, jsonschema.WithDraft("2020-12"), jsonschema.WithFieldNames("proto"))
+		require.NoError(t, err)
+
+		var schema map[string]any
+		require.NoError(t, json.Unmarshal(data, &schema))
+		require.Equal(t, "https://protoc-gen-jsonschema.cerbos.dev/jsonschema/FieldOptions.schema.json", schema["$id"])
+		require.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+		require.Contains(t, schema["properties"], "read_only")
+	})
+
+	t.Run("invalid_option", func(t *testing.T) {
+		_, err := jsonschema.Generate(desc, jsonschema.WithDraft("3"))
+		require.ErrorContains(t, err, "invalid draft parameter")
+	})
+
+	t.Run("file_parameters", func(t *testing.T) {
+		_, err := jsonschema.Generate(desc, jsonschema.WithParameter("layout", "message"))
+		require.ErrorContains(t, err, "the layout parameter does not apply to a single schema")
+
+		_, err = jsonschema.Generate(desc, jsonschema.WithParameter("coverage", "coverage.json"))
+		require.ErrorContains(t, err, "the coverage parameter does not apply to a single schema")
+
+		_, err = jsonschema.Generate(desc, jsonschema.WithParameter("refs", "external"))
+		require.ErrorContains(t, err, `the refs parameter must be "inline" for a single schema`)
+
+		_, err = jsonschema.Generate(desc, jsonschema.WithParameter("refs", "inline"))
+		require.NoError(t, err)
+	})
+}
+
+// TestGenerateMatchesPlugin checks that the schemas match the ones that the plugin generates with the default parameters.
+func TestGenerateMatchesPlugin(t *testing.T) {
+	files := testFiles(t)
+	for _, name := range []string{"testproto.CELTest", "testproto.EditionsTest", "testproto.OneOfRulesTest", "testproto.Proto2Test"} {
+		t.Run(name, func(t *testing.T) {
+			desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+			require.NoError(t, err)
+
+			data, err := jsonschema.Generate(desc.(protoreflect.MessageDescriptor))
+			require.NoError(t, err)
+
+			golden := filepath.Join("default", strings.ReplaceAll(name, ".", "/")+".schema.json")
+			want, err := os.ReadFile(test.PathToDir(t, filepath.Join("golden", golden)))
+			require.NoError(t, err)
+			require.Equal(t, string(want), string(data))
+		})
+	}
+}
+
+// TestGenerateSkippedFile checks that the skip file option, which only stops the plugin generating files, is ignored.
+func TestGenerateSkippedFile(t *testing.T) {
+	desc, err := testFiles(t).FindDescriptorByName("testproto.SkippedTest")
+	require.NoError(t, err)
+
+	data, err := jsonschema.Generate(desc.(protoreflect.MessageDescriptor))
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+	require.Contains(t, schema["properties"], "stringField")
+}
+
+func testFiles(t *testing.T) *protoregistry.Files {
+	t.Helper()
+
+	reqBytes, err := os.ReadFile(test.PathToDir(t, "code_generator_request.pb.bin"))
+	require.NoError(t, err)
+
+	req := &pluginpb.CodeGeneratorRequest{}
+	require.NoError(t, proto.Unmarshal(reqBytes, req))
+
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: req.GetProtoFile()})
+	require.NoError(t, err)
+
+	return files
+}